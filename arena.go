@@ -0,0 +1,100 @@
+package fuzzy
+
+// Arena is a compact source that stores all its lines in a single contiguous block of memory,
+// with a table of offsets to find where every line starts.
+// Compared to a []string, it doesn't need a string header (and often a separate heap object)
+// for every line, so very large sources put much less pressure on the garbage collector.
+// The matches of the searches refer to the index of the lines, in the order they were added.
+type Arena struct {
+	data    []byte
+	offsets []int
+}
+
+// NewArena creates an arena with the lines of the source.
+// The source isn't referenced by the arena, so it can be released after the call.
+func NewArena(source []string) *Arena {
+	size := 0
+	for _, s := range source {
+		size += len(s)
+	}
+
+	a := &Arena{
+		data:    make([]byte, 0, size),
+		offsets: make([]int, 1, len(source)+1),
+	}
+	for _, s := range source {
+		a.Append(s)
+	}
+
+	return a
+}
+
+// Append adds a line at the end of the arena.
+func (a *Arena) Append(s string) {
+	if a.offsets == nil {
+		a.offsets = []int{0}
+	}
+	a.data = append(a.data, s...)
+	a.offsets = append(a.offsets, len(a.data))
+}
+
+// Len returns the number of lines in the arena.
+func (a *Arena) Len() int {
+	return max(0, len(a.offsets)-1)
+}
+
+// At returns the i-th line of the arena (e.g. arena.At(Match.Position)).
+func (a *Arena) At(i int) string {
+	return string(a.line(i))
+}
+
+// Find acts the same as Find on the lines of the arena.
+func (a *Arena) Find(queryValue string, opts ...Option) []Match {
+	return a.find(queryValue, standard, opts)
+}
+
+// LevenshteinFind acts the same as LevenshteinFind on the lines of the arena.
+func (a *Arena) LevenshteinFind(queryValue string, opts ...Option) []Match {
+	return a.find(queryValue, levenshtein, opts)
+}
+
+// line returns the i-th line of the arena, without copying it.
+func (a *Arena) line(i int) []byte {
+	return a.data[a.offsets[i]:a.offsets[i+1]]
+}
+
+// find searches for the query in the arena.
+func (a *Arena) find(queryValue string, algo algorithm, opts []Option) []Match {
+	o := newOptions(opts)
+	q := newQuery(queryValue, &o)
+	sc := getScratch()
+	defer putScratch(sc)
+
+	t := o.track(sc)
+	m := make([]Match, 0, a.Len())
+	for i := range a.Len() {
+		if score := a.score(&q, a.line(i), algo, sc); score >= 0 {
+			m = append(m, Match{Score: score, Position: i})
+		}
+	}
+	t.stop(&o, sc)
+
+	return m
+}
+
+// score calculates the score of a line of the arena.
+// ASCII lines are scored in place, the others are copied to a string to use the Unicode path.
+func (a *Arena) score(q *query, s []byte, algo algorithm, sc *scratch) int {
+	if q.ascii && isASCII(s) {
+		var found bool
+		if sc.buf, found = q.applyASCII(appendASCII(sc.buf[:0], s, !q.keep)); !found {
+			return sc.stats.filtered()
+		}
+		score := algo.scoreNormalizedASCII(q.text, sc.buf, sc)
+		if sc.stats != nil {
+			record(sc.stats, algo, q.text, sc.buf, score)
+		}
+		return score
+	}
+	return algo.score(q, string(s), sc)
+}
@@ -0,0 +1,74 @@
+package fuzzy
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestArena(t *testing.T) {
+	source := []string{
+		"", "test", "Test", "TEST", "testing", "this is a test", "another test", "clap", "cart",
+		"src/main.go", "src/Util/File 10.go", "hello big world test", "Città di Venezia",
+		"  spaces\teverywhere\n", "FooBar", "foo bar", "straße", "ÀÉÎÕÜ",
+	}
+	queries := []string{
+		"", "test", "TEST", "Test", "tst", "tset", "ca", "go", "a b", "città", "Città", "ven",
+		"*this test", "!*another test", "$.go main", "^src go", "?\\d+ file", "!?\\d+",
+		"^hello *world $test", "*xyz", "!$test", "*città", "$ße",
+	}
+
+	a := NewArena(source)
+	if a.Len() != len(source) {
+		t.Errorf("Expected %d lines, got %d", len(source), a.Len())
+	}
+	for i, s := range source {
+		if a.At(i) != s {
+			t.Errorf("Expected line %d to be %q, got %q", i, s, a.At(i))
+		}
+	}
+
+	for _, q := range queries {
+		t.Run(q, func(t *testing.T) {
+			if expected, result := Find(q, source), a.Find(q); !reflect.DeepEqual(result, expected) {
+				t.Errorf("Find: expected %v, got %v", expected, result)
+			}
+			if expected, result := LevenshteinFind(q, source), a.LevenshteinFind(q); !reflect.DeepEqual(result, expected) {
+				t.Errorf("LevenshteinFind: expected %v, got %v", expected, result)
+			}
+		})
+	}
+}
+
+func TestArenaAppend(t *testing.T) {
+	var a Arena
+	if a.Len() != 0 {
+		t.Errorf("Expected an empty arena, got %d lines", a.Len())
+	}
+
+	a.Append("cart")
+	a.Append("")
+	a.Append("clap")
+
+	if a.Len() != 3 || a.At(0) != "cart" || a.At(1) != "" || a.At(2) != "clap" {
+		t.Errorf("Unexpected lines: %d %q %q %q", a.Len(), a.At(0), a.At(1), a.At(2))
+	}
+
+	expected := []Match{{Score: 2, Position: 0}, {Score: 3, Position: 2}}
+	if result := a.Find("ca"); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func BenchmarkArenaFind(b *testing.B) {
+	source := make([]string, 10000)
+	for i := range source {
+		source[i] = fmt.Sprintf("test%d", i)
+	}
+	a := NewArena(source)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		a.Find("test")
+	}
+}
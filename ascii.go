@@ -0,0 +1,178 @@
+package fuzzy
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// isASCII checks if the string contains only ASCII characters.
+func isASCII[T string | []byte](s T) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// isSpaceASCII checks if the ASCII character is a whitespace (the same characters of unicode.IsSpace).
+func isSpaceASCII(c byte) bool {
+	switch c {
+	case '\t', '\n', '\v', '\f', '\r', ' ':
+		return true
+	}
+	return false
+}
+
+// filterASCII is the version of filter for ASCII lines.
+// It writes the normalized line in buf, reusing its memory, so that it doesn't allocate once buf is big enough.
+// The result is the same of filter.
+func (q *query) filterASCII(buf []byte, s string) ([]byte, bool) {
+	return q.applyASCII(appendASCII(buf, s, !q.keep))
+}
+
+// appendASCII appends the ASCII line to buf, lowercasing it if lower is true.
+func appendASCII[T string | []byte](buf []byte, s T, lower bool) []byte {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if lower && 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		buf = append(buf, c)
+	}
+	return buf
+}
+
+// applyASCII is the version of apply for ASCII lines, the line is modified in place.
+// As filter does, it lowercases the line after the filters if it kept its case for them.
+func (q *query) applyASCII(buf []byte) ([]byte, bool) {
+	found := true
+	for _, fv := range q.filters {
+		if !found {
+			return buf[:0], false
+		}
+		buf, found = fv.applyASCII(buf)
+	}
+
+	lower := q.keep && !q.upper
+	n := 0
+	for _, c := range buf {
+		if !isSpaceASCII(c) {
+			if lower && 'A' <= c && c <= 'Z' {
+				c += 'a' - 'A'
+			}
+			buf[n] = c
+			n++
+		}
+	}
+
+	return buf[:n], found
+}
+
+// applyASCII is the version of apply for ASCII lines, the consumed text is removed in place.
+func (fv filter) applyASCII(b []byte) ([]byte, bool) {
+	var found bool
+	switch fv.op {
+	case '?':
+		if fv.re == nil {
+			return b[:0], false
+		}
+		found = fv.re.Match(b)
+	case '$':
+		if fv.fold {
+			found = len(b) >= len(fv.value) && equalFoldASCII(b[len(b)-len(fv.value):], fv.value)
+		} else {
+			found = bytes.HasSuffix(b, []byte(fv.value))
+		}
+		if found {
+			b = b[:len(b)-len(fv.value)]
+		}
+	case '^':
+		if fv.fold {
+			found = len(b) >= len(fv.value) && equalFoldASCII(b[:len(fv.value)], fv.value)
+		} else {
+			found = bytes.HasPrefix(b, []byte(fv.value))
+		}
+		if found {
+			b = b[len(fv.value):]
+		}
+	default:
+		var i int
+		if fv.fold {
+			i = indexFoldASCII(b, fv.value)
+		} else {
+			i = bytes.Index(b, []byte(fv.value))
+		}
+		if i >= 0 {
+			b = append(b[:i], b[i+len(fv.value):]...)
+			found = true
+		}
+	}
+
+	if fv.reverse {
+		found = !found
+	}
+
+	return b, found
+}
+
+// matchScoreASCII is the version of matchScore for ASCII lines, it compares bytes instead of runes.
+// The line must be already normalized with filterASCII.
+func matchScoreASCII(q string, s []byte) int {
+	ql, sl := len(q), len(s)
+
+	// preliminary check to optimize algorithm speed
+	switch {
+	case sl < ql:
+		return -1
+	case string(s) == q, q == "":
+		return 0
+	case bytes.Contains(s, []byte(q)):
+		return sl - ql
+	}
+
+	distance := 0
+	for index := 0; index < ql; index++ {
+		i := bytes.IndexByte(s, q[index])
+		if i < 0 {
+			return -1
+		}
+		s = s[i+1:]
+		if index > 0 {
+			distance += i
+		}
+	}
+
+	return sl - ql + distance
+}
+
+// levenshteinScoreASCII is the version of levenshteinScore for ASCII lines, it compares bytes instead of runes.
+// The line must be already normalized with filterASCII.
+func levenshteinScoreASCII(q string, s []byte, column []int) int {
+	ql, sl := len(q), len(s)
+
+	// preliminary check to optimize algorithm speed
+	switch {
+	case sl < ql:
+		return -1
+	case string(s) == q, q == "":
+		return 0
+	case bytes.Contains(s, []byte(q)):
+		return sl - ql
+	}
+
+	founded := 0
+	minFind := int(float64(ql) * 0.6)
+
+	sc := s
+	for index := 0; index < ql && founded < minFind; index++ {
+		i := bytes.IndexByte(sc, q[index])
+		if i < 0 {
+			return -1
+		}
+		sc = sc[i+1:]
+		founded++
+	}
+
+	return levenshteinDistance(q, s, column)
+}
@@ -0,0 +1,92 @@
+package fuzzy
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestIsASCII(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected bool
+	}{
+		{"", true},
+		{"hello world", true},
+		{"path/to/file_1.go\t", true},
+		{"città", false},
+		{"\u00a0", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			if result := isASCII(tc.input); result != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+// TestASCIIFastPath checks that the ASCII fast path gives the same results of the Unicode path.
+func TestASCIIFastPath(t *testing.T) {
+	queries := []string{
+		"", "test", "TEST", "Test", "tst", "tset", "ca", "clap", "go", "a b",
+		"*this test", "!*another test", "$.go main", "^src go", "?\\d+ file", "!?\\d+",
+		"^hello *world $test", "*xyz", "!$test", "?[", "!?[", "*o w", "*Foo",
+		"foo *Bar", "Foo *bar", "$BAR foo", "^Hello world", "?Foo", "Test ?\\d",
+	}
+	lines := []string{
+		"", "test", "Test", "TEST", "testing", "this is a test", "another test", "clap", "cart",
+		"src/main.go", "src/util/file_10.go", "hello big world test", "hello world",
+		"  spaces\teverywhere\n", "FooBar", "foo bar", "o w",
+	}
+
+	for _, query := range queries {
+		for _, line := range lines {
+			t.Run(fmt.Sprintf("%q in %q", query, line), func(t *testing.T) {
+				q := testQuery(query)
+
+				expectedLine, expectedFound := q.filter(line)
+				buf, found := q.filterASCII(nil, line)
+				if found != expectedFound || (found && string(buf) != expectedLine) {
+					t.Errorf("filterASCII: expected (%q, %v), got (%q, %v)", expectedLine, expectedFound, buf, found)
+				}
+
+				expected, expectedLevenshtein := -1, -1
+				if expectedFound {
+					expected = matchScore(q.text, expectedLine)
+					expectedLevenshtein = levenshteinScore(q.text, expectedLine, make([]int, len(q.text)+1))
+				}
+
+				if result := standard.score(&q, line, &scratch{}); result != expected {
+					t.Errorf("standard: expected %d, got %d", expected, result)
+				}
+				if result := levenshtein.score(&q, line, &scratch{}); result != expectedLevenshtein {
+					t.Errorf("levenshtein: expected %d, got %d", expectedLevenshtein, result)
+				}
+			})
+		}
+	}
+}
+
+func TestFilterASCIIAllocations(t *testing.T) {
+	testCases := []struct {
+		query string
+		line  string
+	}{
+		{"test", "Testing the ASCII fast path"},
+		{"*the $path ^testing fast", "Testing the ASCII fast path"},
+		{"?\\w+ fast", "Testing the ASCII fast path"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			q := testQuery(tc.query)
+			sc := &scratch{}
+			standard.score(&q, tc.line, sc)
+
+			if allocs := testing.AllocsPerRun(100, func() { standard.score(&q, tc.line, sc) }); allocs != 0 {
+				t.Errorf("Expected no allocations, got %v", allocs)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package fuzzy
+
+import (
+	"container/list"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache memoizes the results of the searches, for servers that receive the same queries many times
+// against a source that rarely changes.
+// The results are keyed by the normalized query (e.g. "foo  bar" and "foobar" share the same result),
+// the algorithm and a version of the source chosen by the caller: when the source changes the version
+// must change too, and the results of the old versions are evicted over time.
+// The memory is bounded by the total number of matches kept, evicting the least recently used results
+// (every result counts as its number of matches plus one, so that empty results are bounded too).
+// A Cache is safe for concurrent use.
+type Cache struct {
+	mu      sync.Mutex
+	size    int
+	matches int
+	weight  int
+	order   *list.List
+	items   map[cacheKey]*list.Element
+	hits    uint64
+	misses  uint64
+}
+
+// CacheStats reports the usage of a cache.
+type CacheStats struct {
+	Hits    uint64 // the searches served from the cache
+	Misses  uint64 // the searches that had to be run
+	Results int    // the results currently in the cache
+	Matches int    // the matches currently in the cache
+}
+
+// cacheKey identifies a result in the cache.
+type cacheKey struct {
+	algo    algorithm
+	version uint64
+	query   string
+	syntax  syntax
+}
+
+// cacheItem is a result in the cache.
+type cacheItem struct {
+	key     cacheKey
+	matches []Match
+}
+
+// NewCache creates a cache that keeps at most about size matches across all its results.
+// Results with size matches or more are never cached.
+func NewCache(size int) *Cache {
+	return &Cache{
+		size:  size,
+		order: list.New(),
+		items: make(map[cacheKey]*list.Element),
+	}
+}
+
+// Find returns the result of ChunkFind for the query and the given version of the source,
+// running the search only if the result isn't in the cache.
+// The returned slice is a copy, so it can be modified (e.g. sorted) freely.
+func (c *Cache) Find(version uint64, queryValue string, source []string, opts ...Option) []Match {
+	return c.find(version, queryValue, source, standard, opts)
+}
+
+// LevenshteinFind returns the result of ChunkLevenshteinFind for the query and the given version of the source,
+// running the search only if the result isn't in the cache.
+// The returned slice is a copy, so it can be modified (e.g. sorted) freely.
+func (c *Cache) LevenshteinFind(version uint64, queryValue string, source []string, opts ...Option) []Match {
+	return c.find(version, queryValue, source, levenshtein, opts)
+}
+
+// Stats returns the usage of the cache.
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheStats{
+		Hits:    c.hits,
+		Misses:  c.misses,
+		Results: c.order.Len(),
+		Matches: c.matches,
+	}
+}
+
+// Purge removes all the results from the cache. The hit and miss counters aren't reset.
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	clear(c.items)
+	c.matches, c.weight = 0, 0
+}
+
+// find returns the cached result, or runs the search and caches its result.
+func (c *Cache) find(version uint64, queryValue string, source []string, algo algorithm, opts []Option) []Match {
+	o := newOptions(opts)
+	q := newQuery(queryValue, &o)
+	key := cacheKey{algo: algo, version: version, query: q.key(), syntax: q.syntax}
+
+	start := time.Now()
+	if m, ok := c.get(key); ok {
+		// a hit scans no lines
+		if o.collect() {
+			o.report(Stats{Matches: len(m), Duration: time.Since(start)})
+		}
+		return m
+	}
+
+	m := chunkFind(&q, source, algo, &o)
+	// the result of the search has the capacity of the whole source, so the cache keeps a copy of the exact size
+	kept := make([]Match, len(m))
+	copy(kept, m)
+	c.add(key, kept)
+	return m
+}
+
+// get returns a copy of the cached result, updating the counters.
+func (c *Cache) get(key cacheKey) ([]Match, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+
+	c.hits++
+	c.order.MoveToFront(e)
+	return slices.Clone(e.Value.(*cacheItem).matches), true
+}
+
+// add caches the result, evicting the least recently used ones if needed.
+func (c *Cache) add(key cacheKey, m []Match) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(m)+1 > c.size {
+		return
+	}
+	if _, ok := c.items[key]; ok {
+		// the same search was run concurrently and it's already cached
+		return
+	}
+
+	for c.weight+len(m)+1 > c.size {
+		c.remove(c.order.Back())
+	}
+
+	c.items[key] = c.order.PushFront(&cacheItem{key: key, matches: m})
+	c.matches += len(m)
+	c.weight += len(m) + 1
+}
+
+// remove removes the element from the cache.
+func (c *Cache) remove(e *list.Element) {
+	item := c.order.Remove(e).(*cacheItem)
+	delete(c.items, item.key)
+	c.matches -= len(item.matches)
+	c.weight -= len(item.matches) + 1
+}
+
+// key returns a string that identifies the normalized query: queries with the same key give the same results.
+// Every part is prefixed by its length and every list by its size, so the parts can't be mixed up
+// (e.g. the text "x\x00*foo" and the text "x" with the filter "*foo").
+func (q *query) key() string {
+	b := &strings.Builder{}
+	writeKey(b, q.text)
+	writeCount(b, len(q.filters))
+	for _, fv := range q.filters {
+		writeKey(b, fv.raw)
+	}
+	termsKey(b, q.terms)
+	return b.String()
+}
+
+// writeKey writes a part of a key, prefixed by its length.
+func writeKey(b *strings.Builder, s string) {
+	writeCount(b, len(s))
+	b.WriteString(s)
+}
+
+// writeCount writes the size of a list or the length of a part of a key.
+func writeCount(b *strings.Builder, n int) {
+	b.WriteString(strconv.Itoa(n))
+	b.WriteByte(':')
+}
@@ -0,0 +1,180 @@
+package fuzzy
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	source := []string{"cart", "clap", "ca", "cat", "cow"}
+	c := NewCache(100)
+
+	testCases := []struct {
+		name     string
+		search   func() []Match
+		expected []Match
+		stats    CacheStats
+	}{
+		{
+			name:     "Miss",
+			search:   func() []Match { return c.Find(1, "ca", source) },
+			expected: Find("ca", source),
+			stats:    CacheStats{Misses: 1, Results: 1, Matches: 4},
+		},
+		{
+			name:     "Hit",
+			search:   func() []Match { return c.Find(1, "ca", source) },
+			expected: Find("ca", source),
+			stats:    CacheStats{Hits: 1, Misses: 1, Results: 1, Matches: 4},
+		},
+		{
+			name:     "Hit with the same normalized query",
+			search:   func() []Match { return c.Find(1, "c  a", source) },
+			expected: Find("ca", source),
+			stats:    CacheStats{Hits: 2, Misses: 1, Results: 1, Matches: 4},
+		},
+		{
+			name:     "Miss with a different algorithm",
+			search:   func() []Match { return c.LevenshteinFind(1, "ca", source) },
+			expected: LevenshteinFind("ca", source),
+			stats:    CacheStats{Hits: 2, Misses: 2, Results: 2, Matches: 9},
+		},
+		{
+			name:     "Miss with a different filter",
+			search:   func() []Match { return c.Find(1, "ca $t", source) },
+			expected: Find("ca $t", source),
+			stats:    CacheStats{Hits: 2, Misses: 3, Results: 3, Matches: 11},
+		},
+		{
+			name:     "Miss with a different version",
+			search:   func() []Match { return c.Find(2, "ca", source) },
+			expected: Find("ca", source),
+			stats:    CacheStats{Hits: 2, Misses: 4, Results: 4, Matches: 15},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := tc.search()
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, result)
+			}
+			if stats := c.Stats(); stats != tc.stats {
+				t.Errorf("Expected stats %+v, got %+v", tc.stats, stats)
+			}
+
+			// modifying the result must not affect the cache
+			for i := range result {
+				result[i].Score = -1
+			}
+		})
+	}
+
+	c.Purge()
+	if stats := c.Stats(); stats != (CacheStats{Hits: 2, Misses: 4}) {
+		t.Errorf("Expected an empty cache, got %+v", stats)
+	}
+}
+
+func TestCacheEviction(t *testing.T) {
+	source := []string{"cart", "clap", "ca", "cat", "cow"}
+	c := NewCache(9)
+
+	c.Find(1, "ca", source)  // 4 matches, weight 5
+	c.Find(1, "cow", source) // 1 match, weight 2
+	c.Find(1, "ca", source)  // "ca" is now the most recently used
+	c.Find(1, "t", source)   // 2 matches, weight 3: "cow" is evicted
+
+	if stats := c.Stats(); stats.Results != 2 || stats.Matches != 6 {
+		t.Errorf("Expected 2 results and 6 matches, got %+v", stats)
+	}
+
+	c.Find(1, "ca", source)
+	c.Find(1, "cow", source)
+	if stats := c.Stats(); stats.Hits != 2 || stats.Misses != 4 {
+		t.Errorf("Expected \"ca\" to be cached and \"cow\" to be evicted, got %+v", stats)
+	}
+
+	// a result bigger than the cache is never cached
+	c = NewCache(4)
+	c.Find(1, "c", source)
+	if stats := c.Stats(); stats.Results != 0 {
+		t.Errorf("Expected an empty cache, got %+v", stats)
+	}
+}
+
+func TestCacheKeys(t *testing.T) {
+	source := []string{"x foo", "x\x00*foo", "xfoo bar"}
+
+	// the queries must not share a key because of the separators of their parts
+	testCases := [][]string{
+		{"x *foo", "x\x00*foo"},
+		{"x *foo *bar", "x *foo\x00*bar"},
+		{"x\x001:*foo", "x *foo"},
+	}
+
+	for _, queries := range testCases {
+		t.Run(queries[1], func(t *testing.T) {
+			c := NewCache(100)
+			for _, q := range queries {
+				if expected, result := Find(q, source), c.Find(1, q, source); !reflect.DeepEqual(result, expected) {
+					t.Errorf("Expected %v for %q, got %v", expected, q, result)
+				}
+			}
+			for _, q := range queries {
+				if expected, result := Find(q, source, WithSyntax(SyntaxTerms)), c.Find(1, q, source, WithSyntax(SyntaxTerms)); !reflect.DeepEqual(result, expected) {
+					t.Errorf("Expected %v for the terms %q, got %v", expected, q, result)
+				}
+			}
+		})
+	}
+}
+
+func TestCacheCapacity(t *testing.T) {
+	source := make([]string, 1000)
+	for i := range source {
+		source[i] = fmt.Sprintf("line %d", i)
+	}
+	c := NewCache(100)
+
+	// the cached results must not keep the arrays of the searches, which are as big as the source
+	for _, q := range []string{"xyz", "line 99", "line 1"} {
+		if m := c.Find(1, q, source); !reflect.DeepEqual(m, Find(q, source)) {
+			t.Errorf("Expected the results of Find for %q, got %v", q, m)
+		}
+	}
+	for e := c.order.Front(); e != nil; e = e.Next() {
+		item := e.Value.(*cacheItem)
+		if m := item.matches; cap(m) != len(m) {
+			t.Errorf("Expected the capacity of %q to be %d, got %d", item.key.query, len(m), cap(m))
+		}
+	}
+	if m := c.Find(1, "xyz", source); m == nil || len(m) != 0 {
+		t.Errorf("Expected an empty result from the cache, got %#v", m)
+	}
+}
+
+func TestCacheConcurrency(t *testing.T) {
+	source := []string{"cart", "clap", "ca", "cat", "cow"}
+	c := NewCache(20)
+	queries := []string{"ca", "cow", "t", "c", "a"}
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q := queries[i%len(queries)]
+			if result, expected := c.Find(1, q, source), Find(q, source); !reflect.DeepEqual(result, expected) {
+				t.Errorf("Expected %v, got %v", expected, result)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if stats := c.Stats(); stats.Hits+stats.Misses != 50 {
+		t.Errorf("Expected 50 searches, got %+v", stats)
+	}
+}
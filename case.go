@@ -0,0 +1,172 @@
+package fuzzy
+
+import (
+	resyntax "regexp/syntax"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Case is the way the case sensitivity of a query is decided (see WithCase).
+type Case int
+
+const (
+	// CaseSmart makes every term of the query (the text, a filter or a term of SyntaxFZF and SyntaxTerms)
+	// case sensitive only if it has an uppercase character, e.g. "foo *Bar" matches "FOO Bar" but not "foo bar".
+	// The regexes are case sensitive if they have an uppercase literal, so `?\D+` is case insensitive.
+	CaseSmart Case = iota
+	// CaseIgnore makes every term case insensitive.
+	CaseIgnore
+	// CaseRespect makes every term case sensitive.
+	CaseRespect
+)
+
+// sensitive checks if a term with the value is case sensitive.
+func (s *syntax) sensitive(value string) bool {
+	switch s.casing {
+	case CaseIgnore:
+		return false
+	case CaseRespect:
+		return true
+	}
+	return isUpper(value)
+}
+
+// regexSensitive checks if a regex filter is case sensitive.
+// With CaseSmart only the literals count, not the escapes (`\D`) or the names of the classes (`[[:Alpha:]]`).
+func (s *syntax) regexSensitive(expr string) bool {
+	if s.casing != CaseSmart || !isUpper(expr) {
+		return s.sensitive(expr)
+	}
+	re, err := resyntax.Parse(expr, resyntax.Perl)
+	if err != nil {
+		return isUpper(expr)
+	}
+	return hasUpperLiteral(re)
+}
+
+// hasUpperLiteral checks if the regex has an uppercase literal that isn't already case insensitive (e.g. "(?i)A").
+func hasUpperLiteral(re *resyntax.Regexp) bool {
+	if re.Op == resyntax.OpLiteral && re.Flags&resyntax.FoldCase == 0 {
+		for _, r := range re.Rune {
+			if unicode.IsUpper(r) {
+				return true
+			}
+		}
+	}
+	for _, sub := range re.Sub {
+		if hasUpperLiteral(sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// fold marks the filters that must ignore the case of a line that isn't lowercased (keep is true),
+// e.g. "*bar" in "*bar *Foo". The regexes ignore the case also when the line is lowercased, if they have uppercase
+// characters (e.g. `?\D` with CaseIgnore).
+func fold(f []filter, keep bool) {
+	for i := range f {
+		fv := &f[i]
+		switch {
+		case fv.terms != nil:
+			fold(fv.terms, keep)
+		case fv.op == '?':
+			fv.fold = !fv.sensitive && (keep || isUpper(fv.value))
+		default:
+			fv.fold = !fv.sensitive && keep
+		}
+	}
+}
+
+// sensitiveFilters checks if any of the filters is case sensitive.
+func sensitiveFilters(f []filter) bool {
+	for _, fv := range f {
+		if fv.terms != nil && sensitiveFilters(fv.terms) || fv.terms == nil && fv.sensitive {
+			return true
+		}
+	}
+	return false
+}
+
+// prefixFold returns the length in bytes of the prefix of s equal to the lowercased value ignoring the case,
+// or -1 if s doesn't start with the value. The runes of s are lowercased with the foldings (e.g. "ß" is "ss"
+// with FoldCase).
+func prefixFold(s, value string, f Folding) int {
+	var buf [3 * utf8.UTFMax]byte
+	i := 0
+	for value != "" {
+		if i >= len(s) {
+			return -1
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		l := appendCased(buf[:0], r, f, true)
+		if len(value) < len(l) || value[:len(l)] != string(l) {
+			return -1
+		}
+		value = value[len(l):]
+		i += size
+	}
+	return i
+}
+
+// suffixFold returns the length in bytes of the suffix of s equal to the lowercased value ignoring the case,
+// or -1 if s doesn't end with the value.
+func suffixFold(s, value string, f Folding) int {
+	var buf [3 * utf8.UTFMax]byte
+	i := len(s)
+	for value != "" {
+		if i <= 0 {
+			return -1
+		}
+		r, size := utf8.DecodeLastRuneInString(s[:i])
+		l := appendCased(buf[:0], r, f, true)
+		if len(value) < len(l) || value[len(value)-len(l):] != string(l) {
+			return -1
+		}
+		value = value[:len(value)-len(l)]
+		i -= size
+	}
+	return len(s) - i
+}
+
+// cutFold is the version of strings.Cut that ignores the case, for a lowercased value.
+func cutFold(s, value string, f Folding) (string, string, bool) {
+	for i := 0; i <= len(s); {
+		if n := prefixFold(s[i:], value, f); n >= 0 {
+			return s[:i], s[i+n:], true
+		}
+		if i == len(s) {
+			break
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+	return s, "", false
+}
+
+// equalFoldASCII checks if the ASCII bytes are equal to the lowercased value, ignoring their case.
+// It's the version of prefixFold for ASCII lines: a value with other characters is never equal.
+func equalFoldASCII(b []byte, value string) bool {
+	if len(b) != len(value) {
+		return false
+	}
+	for i, c := range b {
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		if c != value[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// indexFoldASCII is the version of cutFold for ASCII lines, it returns the index of the value or -1.
+func indexFoldASCII(b []byte, value string) int {
+	for i := 0; i+len(value) <= len(b); i++ {
+		if equalFoldASCII(b[i:i+len(value)], value) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,146 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCase(t *testing.T) {
+	source := []string{"Foo bar", "foo Bar", "FOO BAR", "foo bar", "Straße Köln", "main.GO"}
+
+	testCases := []struct {
+		name     string
+		query    string
+		opts     []Option
+		expected []int
+	}{
+		{name: "Insensitive", query: "foo", expected: []int{0, 1, 2, 3}},
+		{name: "Sensitive text", query: "Foo", expected: []int{0}},
+		{name: "Sensitive filter with insensitive text", query: "foo *Bar", expected: []int{1}},
+		{name: "Insensitive filter with sensitive text", query: "Foo *bar", expected: []int{0}},
+		{name: "Insensitive filters", query: "$bar ^foo", expected: []int{0, 1, 2, 3}},
+		{name: "Sensitive suffix", query: "$BAR", expected: []int{2}},
+		{name: "Sensitive prefix", query: "^FOO bar", expected: []int{2}},
+		{name: "Negated sensitive filter", query: "foo !*Bar", expected: []int{0, 2, 3}},
+		{name: "Insensitive regex", query: "?^foo", expected: []int{0, 1, 2, 3}},
+		{name: "Sensitive regex", query: "?^F", expected: []int{0, 2}},
+		{name: "Regex escapes aren't literals", query: `?\Wbar`, expected: []int{0, 1, 2, 3}},
+		{name: "Non-ASCII filter", query: "*STRASSE | *Straße", expected: []int{4}},
+		{name: "Insensitive unicode filter", query: "*köln Straße", expected: []int{4}},
+		{name: "Ignore", query: "Foo *BAR", opts: []Option{WithCase(CaseIgnore)}, expected: []int{0, 1, 2, 3}},
+		{name: "Ignore regex", query: "?^F", opts: []Option{WithCase(CaseIgnore)}, expected: []int{0, 1, 2, 3}},
+		{name: "Respect", query: "foo *bar", opts: []Option{WithCase(CaseRespect)}, expected: []int{3}},
+		{name: "Respect regex", query: "?go$", opts: []Option{WithCase(CaseRespect)}, expected: []int{}},
+		{name: "Mixed suffix", query: "main $.go", expected: []int{5}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := make([]int, 0)
+			for _, m := range Find(tc.query, source, tc.opts...) {
+				result = append(result, m.Position)
+			}
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Expected lines %v, got %v", tc.expected, result)
+			}
+
+			// every entry point must decide the case in the same way
+			expected := Find(tc.query, source, tc.opts...)
+			if m := NewCorpus(source).Find(tc.query, tc.opts...); !reflect.DeepEqual(m, expected) {
+				t.Errorf("Corpus.Find: expected %v, got %v", expected, m)
+			}
+			if m := NewArena(source).Find(tc.query, tc.opts...); !reflect.DeepEqual(m, expected) {
+				t.Errorf("Arena.Find: expected %v, got %v", expected, m)
+			}
+			for _, m := range expected {
+				if e := Explain(tc.query, source[m.Position], tc.opts...); e.Score != m.Score {
+					t.Errorf("Explain: expected %d for line %d, got %+v", m.Score, m.Position, e)
+				}
+			}
+		})
+	}
+}
+
+func TestCaseSyntaxes(t *testing.T) {
+	source := []string{"src/Config.go", "src/config.go", "SRC/config.go"}
+
+	testCases := []struct {
+		name     string
+		query    string
+		opts     []Option
+		expected []int
+	}{
+		{name: "fzf", query: "'Config ^src", opts: []Option{WithSyntax(SyntaxFZF)}, expected: []int{0}},
+		{name: "fzf respect", query: "'config ^src", opts: []Option{WithSyntax(SyntaxFZF), WithCase(CaseRespect)}, expected: []int{1}},
+		{name: "fzf ignore", query: "'Config ^SRC", opts: []Option{WithSyntax(SyntaxFZF), WithCase(CaseIgnore)}, expected: []int{0, 1, 2}},
+		{name: "Terms", query: "go ^SRC", opts: []Option{WithSyntax(SyntaxTerms)}, expected: []int{2}},
+		{name: "Terms with sensitive term", query: "Config ^src", opts: []Option{WithSyntax(SyntaxTerms)}, expected: []int{0}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := make([]int, 0)
+			for _, m := range Find(tc.query, source, tc.opts...) {
+				result = append(result, m.Position)
+			}
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Expected lines %v, got %v", tc.expected, result)
+			}
+			if m := NewCorpus(source).Find(tc.query, tc.opts...); !reflect.DeepEqual(m, Find(tc.query, source, tc.opts...)) {
+				t.Errorf("Corpus.Find: expected the same matches of Find, got %v", m)
+			}
+		})
+	}
+}
+
+func TestCaseKeys(t *testing.T) {
+	source := []string{"Foo", "foo"}
+
+	c := NewCache(100)
+	c.Find(1, "foo", source)
+	if m := c.Find(1, "foo", source, WithCase(CaseRespect)); len(m) != 1 || m[0].Position != 1 {
+		t.Errorf("Expected the cache to tell the case options apart, got %v", m)
+	}
+
+	in := NewIncremental(source)
+	in.Find("fo")
+	if m := in.Find("foO", WithCase(CaseIgnore)); len(m) != 2 {
+		t.Errorf("Expected the incremental search to match both lines, got %v", m)
+	}
+
+	e := Explain("foo *Bar", "foo Bar")
+	if e.CaseSensitive || len(e.Filters) != 1 || !e.Filters[0].CaseSensitive || e.Filters[0].Removed != "Bar" {
+		t.Errorf("Expected an insensitive text and a sensitive filter, got %+v", e)
+	}
+}
+
+func TestFoldHelpers(t *testing.T) {
+	testCases := []struct {
+		s, value       string
+		prefix, suffix int
+		before, after  string
+		found          bool
+	}{
+		{s: "FooBar", value: "foo", prefix: 3, suffix: -1, before: "", after: "Bar", found: true},
+		{s: "FooBar", value: "bar", prefix: -1, suffix: 3, before: "Foo", after: "", found: true},
+		{s: "FooBar", value: "obar", prefix: -1, suffix: 4, before: "Fo", after: "", found: true},
+		{s: "ÀBC", value: "àb", prefix: 3, suffix: -1, before: "", after: "C", found: true},
+		{s: "Kelvin", value: "kelvin", prefix: 8, suffix: 8, before: "", after: "", found: true},
+		{s: "abc", value: "", prefix: 0, suffix: 0, before: "", after: "abc", found: true},
+		{s: "ab", value: "abc", prefix: -1, suffix: -1, before: "ab", after: "", found: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.s+"/"+tc.value, func(t *testing.T) {
+			if n := prefixFold(tc.s, tc.value, 0); n != tc.prefix {
+				t.Errorf("prefixFold: expected %d, got %d", tc.prefix, n)
+			}
+			if n := suffixFold(tc.s, tc.value, 0); n != tc.suffix {
+				t.Errorf("suffixFold: expected %d, got %d", tc.suffix, n)
+			}
+			if before, after, found := cutFold(tc.s, tc.value, 0); before != tc.before || after != tc.after || found != tc.found {
+				t.Errorf("cutFold: expected (%q, %q, %v), got (%q, %q, %v)", tc.before, tc.after, tc.found, before, after, found)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package fuzzy
+
+import "unicode/utf8"
+
+// The CJK foldings: the full-width and half-width forms (FoldWidth) and the kana (FoldKana).
+
+// widths are the half-width forms and the full-width forms outside of the ASCII range, derived from their
+// compatibility forms (NFKC). The full-width ASCII forms ("Ａ" is "A") are folded by narrow.
+var widths = map[rune]rune{
+	'｟': '⦅', // fullwidth left white parenthesis
+	'｠': '⦆', // fullwidth right white parenthesis
+	'｡': '。', // halfwidth ideographic full stop
+	'｢': '「', // halfwidth left corner bracket
+	'｣': '」', // halfwidth right corner bracket
+	'､': '、', // halfwidth ideographic comma
+	'･': '・', // halfwidth katakana middle dot
+	'ｦ': 'ヲ', // halfwidth katakana letter wo
+	'ｧ': 'ァ', // halfwidth katakana letter small a
+	'ｨ': 'ィ', // halfwidth katakana letter small i
+	'ｩ': 'ゥ', // halfwidth katakana letter small u
+	'ｪ': 'ェ', // halfwidth katakana letter small e
+	'ｫ': 'ォ', // halfwidth katakana letter small o
+	'ｬ': 'ャ', // halfwidth katakana letter small ya
+	'ｭ': 'ュ', // halfwidth katakana letter small yu
+	'ｮ': 'ョ', // halfwidth katakana letter small yo
+	'ｯ': 'ッ', // halfwidth katakana letter small tu
+	'ｰ': 'ー', // halfwidth katakana-hiragana prolonged sound mark
+	'ｱ': 'ア', // halfwidth katakana letter a
+	'ｲ': 'イ', // halfwidth katakana letter i
+	'ｳ': 'ウ', // halfwidth katakana letter u
+	'ｴ': 'エ', // halfwidth katakana letter e
+	'ｵ': 'オ', // halfwidth katakana letter o
+	'ｶ': 'カ', // halfwidth katakana letter ka
+	'ｷ': 'キ', // halfwidth katakana letter ki
+	'ｸ': 'ク', // halfwidth katakana letter ku
+	'ｹ': 'ケ', // halfwidth katakana letter ke
+	'ｺ': 'コ', // halfwidth katakana letter ko
+	'ｻ': 'サ', // halfwidth katakana letter sa
+	'ｼ': 'シ', // halfwidth katakana letter si
+	'ｽ': 'ス', // halfwidth katakana letter su
+	'ｾ': 'セ', // halfwidth katakana letter se
+	'ｿ': 'ソ', // halfwidth katakana letter so
+	'ﾀ': 'タ', // halfwidth katakana letter ta
+	'ﾁ': 'チ', // halfwidth katakana letter ti
+	'ﾂ': 'ツ', // halfwidth katakana letter tu
+	'ﾃ': 'テ', // halfwidth katakana letter te
+	'ﾄ': 'ト', // halfwidth katakana letter to
+	'ﾅ': 'ナ', // halfwidth katakana letter na
+	'ﾆ': 'ニ', // halfwidth katakana letter ni
+	'ﾇ': 'ヌ', // halfwidth katakana letter nu
+	'ﾈ': 'ネ', // halfwidth katakana letter ne
+	'ﾉ': 'ノ', // halfwidth katakana letter no
+	'ﾊ': 'ハ', // halfwidth katakana letter ha
+	'ﾋ': 'ヒ', // halfwidth katakana letter hi
+	'ﾌ': 'フ', // halfwidth katakana letter hu
+	'ﾍ': 'ヘ', // halfwidth katakana letter he
+	'ﾎ': 'ホ', // halfwidth katakana letter ho
+	'ﾏ': 'マ', // halfwidth katakana letter ma
+	'ﾐ': 'ミ', // halfwidth katakana letter mi
+	'ﾑ': 'ム', // halfwidth katakana letter mu
+	'ﾒ': 'メ', // halfwidth katakana letter me
+	'ﾓ': 'モ', // halfwidth katakana letter mo
+	'ﾔ': 'ヤ', // halfwidth katakana letter ya
+	'ﾕ': 'ユ', // halfwidth katakana letter yu
+	'ﾖ': 'ヨ', // halfwidth katakana letter yo
+	'ﾗ': 'ラ', // halfwidth katakana letter ra
+	'ﾘ': 'リ', // halfwidth katakana letter ri
+	'ﾙ': 'ル', // halfwidth katakana letter ru
+	'ﾚ': 'レ', // halfwidth katakana letter re
+	'ﾛ': 'ロ', // halfwidth katakana letter ro
+	'ﾜ': 'ワ', // halfwidth katakana letter wa
+	'ﾝ': 'ン', // halfwidth katakana letter n
+	'ﾞ': '゛', // halfwidth katakana voiced sound mark
+	'ﾟ': '゜', // halfwidth katakana semi-voiced sound mark
+	'ﾠ': 'ᅠ', // halfwidth hangul filler
+	'ﾡ': 'ᄀ', // halfwidth hangul letter kiyeok
+	'ﾢ': 'ᄁ', // halfwidth hangul letter ssangkiyeok
+	'ﾣ': 'ᆪ', // halfwidth hangul letter kiyeok-sios
+	'ﾤ': 'ᄂ', // halfwidth hangul letter nieun
+	'ﾥ': 'ᆬ', // halfwidth hangul letter nieun-cieuc
+	'ﾦ': 'ᆭ', // halfwidth hangul letter nieun-hieuh
+	'ﾧ': 'ᄃ', // halfwidth hangul letter tikeut
+	'ﾨ': 'ᄄ', // halfwidth hangul letter ssangtikeut
+	'ﾩ': 'ᄅ', // halfwidth hangul letter rieul
+	'ﾪ': 'ᆰ', // halfwidth hangul letter rieul-kiyeok
+	'ﾫ': 'ᆱ', // halfwidth hangul letter rieul-mieum
+	'ﾬ': 'ᆲ', // halfwidth hangul letter rieul-pieup
+	'ﾭ': 'ᆳ', // halfwidth hangul letter rieul-sios
+	'ﾮ': 'ᆴ', // halfwidth hangul letter rieul-thieuth
+	'ﾯ': 'ᆵ', // halfwidth hangul letter rieul-phieuph
+	'ﾰ': 'ᄚ', // halfwidth hangul letter rieul-hieuh
+	'ﾱ': 'ᄆ', // halfwidth hangul letter mieum
+	'ﾲ': 'ᄇ', // halfwidth hangul letter pieup
+	'ﾳ': 'ᄈ', // halfwidth hangul letter ssangpieup
+	'ﾴ': 'ᄡ', // halfwidth hangul letter pieup-sios
+	'ﾵ': 'ᄉ', // halfwidth hangul letter sios
+	'ﾶ': 'ᄊ', // halfwidth hangul letter ssangsios
+	'ﾷ': 'ᄋ', // halfwidth hangul letter ieung
+	'ﾸ': 'ᄌ', // halfwidth hangul letter cieuc
+	'ﾹ': 'ᄍ', // halfwidth hangul letter ssangcieuc
+	'ﾺ': 'ᄎ', // halfwidth hangul letter chieuch
+	'ﾻ': 'ᄏ', // halfwidth hangul letter khieukh
+	'ﾼ': 'ᄐ', // halfwidth hangul letter thieuth
+	'ﾽ': 'ᄑ', // halfwidth hangul letter phieuph
+	'ﾾ': 'ᄒ', // halfwidth hangul letter hieuh
+	'ￂ': 'ᅡ', // halfwidth hangul letter a
+	'ￃ': 'ᅢ', // halfwidth hangul letter ae
+	'ￄ': 'ᅣ', // halfwidth hangul letter ya
+	'ￅ': 'ᅤ', // halfwidth hangul letter yae
+	'ￆ': 'ᅥ', // halfwidth hangul letter eo
+	'ￇ': 'ᅦ', // halfwidth hangul letter e
+	'ￊ': 'ᅧ', // halfwidth hangul letter yeo
+	'ￋ': 'ᅨ', // halfwidth hangul letter ye
+	'ￌ': 'ᅩ', // halfwidth hangul letter o
+	'ￍ': 'ᅪ', // halfwidth hangul letter wa
+	'ￎ': 'ᅫ', // halfwidth hangul letter wae
+	'ￏ': 'ᅬ', // halfwidth hangul letter oe
+	'ￒ': 'ᅭ', // halfwidth hangul letter yo
+	'ￓ': 'ᅮ', // halfwidth hangul letter u
+	'ￔ': 'ᅯ', // halfwidth hangul letter weo
+	'ￕ': 'ᅰ', // halfwidth hangul letter we
+	'ￖ': 'ᅱ', // halfwidth hangul letter wi
+	'ￗ': 'ᅲ', // halfwidth hangul letter yu
+	'ￚ': 'ᅳ', // halfwidth hangul letter eu
+	'ￛ': 'ᅴ', // halfwidth hangul letter yi
+	'ￜ': 'ᅵ', // halfwidth hangul letter i
+	'￠': '¢', // fullwidth cent sign
+	'￡': '£', // fullwidth pound sign
+	'￢': '¬', // fullwidth not sign
+	'￣': '¯', // fullwidth macron
+	'￤': '¦', // fullwidth broken bar
+	'￥': '¥', // fullwidth yen sign
+	'￦': '₩', // fullwidth won sign
+	'￨': '│', // halfwidth forms light vertical
+	'￩': '←', // halfwidth leftwards arrow
+	'￪': '↑', // halfwidth upwards arrow
+	'￫': '→', // halfwidth rightwards arrow
+	'￬': '↓', // halfwidth downwards arrow
+	'￭': '■', // halfwidth black square
+	'￮': '○', // halfwidth white circle
+}
+
+// narrow returns the form of the rune that isn't full-width or half-width, e.g. "Ａ" is "A" and "ｶ" is "カ".
+func narrow(r rune) rune {
+	switch {
+	case r == '　':
+		return ' '
+	case '！' <= r && r <= '～':
+		return r - 0xFEE0
+	}
+	if n, ok := widths[r]; ok {
+		return n
+	}
+	return r
+}
+
+// hiragana returns the hiragana of a katakana, e.g. "カ" is "か".
+// The katakana without a hiragana (e.g. "ヷ") and the prolonged sound mark ("ー") are returned as they are.
+func hiragana(r rune) rune {
+	switch {
+	case 'ァ' <= r && r <= 'ヶ', r == 'ヽ', r == 'ヾ':
+		return r - 0x60
+	}
+	return r
+}
+
+// appendVoiced composes the voiced sound mark (the combining "゙" or the half-width "ﾞ")
+// or the semi-voiced one ("゚" or "ﾟ") with the kana at the end of b, e.g. "カ" and "ﾞ" are "ガ".
+// It reports false if r isn't a sound mark or the kana can't have it.
+func appendVoiced(b []byte, r rune) ([]byte, bool) {
+	var semi bool
+	switch r {
+	case '゙', 'ﾞ':
+	case '゚', 'ﾟ':
+		semi = true
+	default:
+		return b, false
+	}
+
+	k, size := utf8.DecodeLastRune(b)
+	if c := voiced(k, semi); c != k {
+		return utf8.AppendRune(b[:len(b)-size], c), true
+	}
+	return b, false
+}
+
+// voiced returns the kana with the voiced (or semi-voiced) sound mark, or the kana itself if it can't have it.
+func voiced(k rune, semi bool) rune {
+	// the katakana are offset from the hiragana by 0x60
+	r, offset := k, rune(0)
+	if 'ァ' <= k && k <= 'ヺ' {
+		r, offset = k-0x60, 0x60
+	}
+
+	switch {
+	case 'は' <= r && r <= 'ぽ' && (r-'は')%3 == 0:
+		if semi {
+			return r + 2 + offset
+		}
+		return r + 1 + offset
+	case semi:
+		return k
+	case 'か' <= r && r <= 'ぢ' && (r-'か')%2 == 0, r == 'つ', r == 'て', r == 'と':
+		return r + 1 + offset
+	case r == 'う':
+		return 'ゔ' + offset
+	case offset != 0 && 'ワ' <= k && k <= 'ヲ':
+		return k + 8
+	}
+	return k
+}
@@ -0,0 +1,90 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeCJK(t *testing.T) {
+	testCases := []struct {
+		name     string
+		folding  Folding
+		input    string
+		expected string
+	}{
+		{name: "Full-width ASCII", folding: FoldWidth, input: "ＡＢＣ１２３！", expected: "abc123!"},
+		{name: "Ideographic space", folding: FoldWidth, input: "東京　駅", expected: "東京 駅"},
+		{name: "Half-width katakana", folding: FoldWidth, input: "ｶﾀｶﾅ", expected: "カタカナ"},
+		{name: "Half-width voiced", folding: FoldWidth, input: "ｶﾞｷﾞﾊﾟﾋﾞｳﾞ", expected: "ガギパビヴ"},
+		{name: "Combining voiced", folding: FoldWidth, input: "がぱヷ", expected: "がぱヷ"},
+		{name: "Lone sound mark", folding: FoldWidth, input: "ﾞaｰ", expected: "゛aー"},
+		{name: "Mark that can't be composed", folding: FoldWidth, input: "ｱﾞ", expected: "ア゛"},
+		{name: "Half-width symbols", folding: FoldWidth, input: "｢ﾃｽﾄ｣￥", expected: "「テスト」¥"},
+		{name: "Kana", folding: FoldKana, input: "カタカナ ヴ ヽ ー", expected: "かたかな ゔ ゝ ー"},
+		{name: "Width and kana", folding: FoldWidth | FoldKana, input: "ｶﾞｯｺｳ", expected: "がっこう"},
+		{name: "Katakana without hiragana", folding: FoldKana, input: "ヷ", expected: "ヷ"},
+		{name: "All", folding: FoldAll, input: "ﾃﾞｰﾀ Ｃａｆé", expected: "でーた cafe"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			syn := syntax{folding: tc.folding}
+			if result := syn.normalize(tc.input, true); result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestFindCJK(t *testing.T) {
+	source := []string{"ＡＢＣ株式会社", "ABC商事", "ｶﾞｯｺｳ", "がっこう", "ガッコウ", "テスト.txt"}
+
+	testCases := []struct {
+		name     string
+		query    string
+		opts     []Option
+		expected []int
+	}{
+		{name: "No folding", query: "abc", expected: []int{1}},
+		{name: "Width", query: "abc", opts: []Option{WithFolding(FoldWidth)}, expected: []int{0, 1}},
+		{name: "Full-width query", query: "ａｂｃ", opts: []Option{WithFolding(FoldWidth)}, expected: []int{0, 1}},
+		{name: "Half-width katakana", query: "ガッコウ", opts: []Option{WithFolding(FoldWidth)}, expected: []int{2, 4}},
+		{name: "Kana", query: "がっこう", opts: []Option{WithFolding(FoldKana)}, expected: []int{3, 4}},
+		{name: "Width and kana", query: "がっこう", opts: []Option{WithFolding(FoldWidth | FoldKana)}, expected: []int{2, 3, 4}},
+		{name: "Filters", query: "$.txt ^てすと", opts: []Option{WithFolding(FoldAll)}, expected: []int{5}},
+		{name: "Terms", query: "abc 会社", opts: []Option{WithFolding(FoldAll), WithSyntax(SyntaxTerms)}, expected: []int{0}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := make([]int, 0)
+			for _, m := range Find(tc.query, source, tc.opts...) {
+				result = append(result, m.Position)
+			}
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Expected lines %v, got %v", tc.expected, result)
+			}
+
+			expected := Find(tc.query, source, tc.opts...)
+			if m := NewCorpus(source).Find(tc.query, tc.opts...); !reflect.DeepEqual(m, expected) {
+				t.Errorf("Corpus.Find: expected %v, got %v", expected, m)
+			}
+			if m := NewArena(source).Find(tc.query, tc.opts...); !reflect.DeepEqual(m, expected) {
+				t.Errorf("Arena.Find: expected %v, got %v", expected, m)
+			}
+		})
+	}
+}
+
+func TestCJKPositions(t *testing.T) {
+	// the sound mark of "ｶﾞ" is composed with the kana, which keeps the offset of "ｶ"
+	e := Explain("がこ", "ｶﾞｯｺｳ", WithFolding(FoldWidth|FoldKana))
+	if expected := []int{0, 9}; !reflect.DeepEqual(e.Positions, expected) {
+		t.Errorf("Expected the positions %v, got %+v", expected, e)
+	}
+
+	e = Explain("bc", "ＡＢＣ", WithFolding(FoldWidth))
+	if expected := []int{3, 6}; !reflect.DeepEqual(e.Positions, expected) {
+		t.Errorf("Expected the positions %v, got %+v", expected, e)
+	}
+}
@@ -0,0 +1,137 @@
+package fuzzy
+
+import "strings"
+
+// Corpus is a source prepared for repeated searches: every line is normalized once when the
+// corpus is created (lowercased, without whitespace, etc.), so that the searches don't repeat
+// that work for every query. The standard search also skips with a single bitwise AND the lines
+// that don't contain all the runes of the query (see runeMask).
+// The results of the searches are the same of Find and LevenshteinFind on the original source.
+// A Corpus is read-only once created, so it's safe for concurrent use.
+type Corpus struct {
+	source  []string
+	entries []entry
+}
+
+// entry is the normalized form of a line of the corpus.
+type entry struct {
+	lower   string // the lowercased line, used by the filters
+	compact string // the lowercased line without whitespace, scored when the query has no filters
+	mask    uint64 // the runes in the line (see runeMask)
+	ascii   bool   // true if the line contains only ASCII characters
+}
+
+// NewCorpus prepares the source for repeated searches.
+// The source must not be modified while the corpus is in use.
+func NewCorpus(source []string) *Corpus {
+	c := &Corpus{
+		source:  source,
+		entries: make([]entry, len(source)),
+	}
+
+	for i, s := range source {
+		lower := strings.ToLower(s)
+		c.entries[i] = entry{
+			lower:   lower,
+			compact: removeWhitespace(lower),
+			mask:    runeMask(lower),
+			ascii:   isASCII(s),
+		}
+	}
+
+	return c
+}
+
+// Len returns the number of lines in the corpus.
+func (c *Corpus) Len() int {
+	return len(c.source)
+}
+
+// Source returns the lines of the corpus (e.g. corpus.Source()[Match.Position]).
+func (c *Corpus) Source() []string {
+	return c.source
+}
+
+// Find acts the same as Find on the source of the corpus, using the cached normalized lines.
+func (c *Corpus) Find(queryValue string, opts ...Option) []Match {
+	return c.find(queryValue, standard, opts)
+}
+
+// LevenshteinFind acts the same as LevenshteinFind on the source of the corpus, using the cached normalized lines.
+func (c *Corpus) LevenshteinFind(queryValue string, opts ...Option) []Match {
+	return c.find(queryValue, levenshtein, opts)
+}
+
+// find searches for the query in the corpus.
+func (c *Corpus) find(queryValue string, algo algorithm, opts []Option) []Match {
+	o := newOptions(opts)
+	q := newQuery(queryValue, &o)
+	sc := getScratch()
+	defer putScratch(sc)
+
+	t := o.track(sc)
+	m := make([]Match, 0, len(c.entries))
+	for i := range c.entries {
+		if score := c.score(&q, i, algo, sc); score >= 0 {
+			m = append(m, Match{Score: score, Position: i})
+		}
+	}
+	t.stop(&o, sc)
+
+	return m
+}
+
+// score calculates the score of the i-th line of the corpus.
+// The lines are counted in the statistics as Find counts them, so the rune masks aren't used while they are collected.
+func (c *Corpus) score(q *query, i int, algo algorithm, sc *scratch) int {
+	e := &c.entries[i]
+
+	// the standard algorithm needs every rune of the query in the line:
+	// if a rune is missing from the mask of the line, it can't be a match
+	if algo == standard && sc.stats == nil && e.mask&q.mask != q.mask {
+		return -1
+	}
+
+	// the cached forms are lowercased and not folded, so they can't be used if a part of the query
+	// is case sensitive or the query has foldings
+	if q.keep || q.syntax.folding != 0 {
+		return algo.score(q, c.source[i], sc)
+	}
+
+	if q.terms != nil && len(q.filters) == 0 {
+		return sc.stats.scored(algo.scoreTerms(q, c.source[i], e.lower, sc))
+	}
+
+	if len(q.filters) == 0 {
+		score := algo.scoreNormalized(q.text, e.compact, sc)
+		if sc.stats != nil {
+			record(sc.stats, algo, q.text, e.compact, score)
+		}
+		return score
+	}
+
+	if q.ascii && e.ascii {
+		var found bool
+		if sc.buf, found = q.applyASCII(append(sc.buf[:0], e.lower...)); !found {
+			return sc.stats.filtered()
+		}
+		score := algo.scoreNormalizedASCII(q.text, sc.buf, sc)
+		if sc.stats != nil {
+			record(sc.stats, algo, q.text, sc.buf, score)
+		}
+		return score
+	}
+
+	s, found := q.apply(e.lower)
+	switch {
+	case !found:
+		return sc.stats.filtered()
+	case q.terms != nil:
+		return sc.stats.scored(algo.scoreTerms(q, s, s, sc))
+	}
+	score := algo.scoreNormalized(q.text, s, sc)
+	if sc.stats != nil {
+		record(sc.stats, algo, q.text, s, score)
+	}
+	return score
+}
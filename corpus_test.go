@@ -0,0 +1,129 @@
+package fuzzy
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestCorpus(t *testing.T) {
+	source := []string{
+		"", "test", "Test", "TEST", "testing", "this is a test", "another test", "clap", "cart",
+		"src/main.go", "src/Util/File 10.go", "hello big world test", "Città di Venezia",
+		"  spaces\teverywhere\n", "FooBar", "foo bar", "straße", "ÀÉÎÕÜ",
+		"İstanbul", "273 \u212A",
+	}
+	queries := []string{
+		"", "test", "TEST", "Test", "tst", "tset", "ca", "go", "a b", "città", "Città", "ven",
+		"*this test", "!*another test", "$.go main", "^src go", "?\\d+ file", "!?\\d+",
+		"^hello *world $test", "*xyz", "!$test", "*città", "*Foo", "$ße",
+		"($.go | $test) !*util", "!(^src $.go)", "test | *clap",
+		"İ", "İst", "\u212A", "3\u212A",
+	}
+
+	c := NewCorpus(source)
+	if c.Len() != len(source) {
+		t.Errorf("Expected %d lines, got %d", len(source), c.Len())
+	}
+	if !reflect.DeepEqual(c.Source(), source) {
+		t.Errorf("Expected the original source, got %v", c.Source())
+	}
+
+	for _, q := range queries {
+		t.Run(q, func(t *testing.T) {
+			if expected, result := Find(q, source), c.Find(q); !reflect.DeepEqual(result, expected) {
+				t.Errorf("Find: expected %v, got %v", expected, result)
+			}
+			if expected, result := LevenshteinFind(q, source), c.LevenshteinFind(q); !reflect.DeepEqual(result, expected) {
+				t.Errorf("LevenshteinFind: expected %v, got %v", expected, result)
+			}
+		})
+	}
+}
+
+func TestRuneMask(t *testing.T) {
+	testCases := []struct {
+		line     string
+		query    string
+		expected bool
+	}{
+		{"hello world", "hlo", true},
+		{"Hello World", "hw", true},
+		{"hello", "hx", false},
+		{"file_10.go", "f1.g", true},
+		{"file_10.go", "f2", false},
+		{"città", "tà", true},
+		{"CITTÀ", "tà", true},
+		{"a b", "ab", true},
+		{"istanbul", "İ", true},
+		{"273 k", "\u212A", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.line+" "+tc.query, func(t *testing.T) {
+			line, query := runeMask(tc.line), runeMask(tc.query)
+			if result := line&query == query; result != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func BenchmarkCorpusFind(b *testing.B) {
+	source := make([]string, 10000)
+	for i := range source {
+		source[i] = fmt.Sprintf("Test Line %d", i)
+	}
+	c := NewCorpus(source)
+
+	b.Run("Find", func(b *testing.B) {
+		for b.Loop() {
+			Find("test", source)
+		}
+	})
+
+	b.Run("Corpus", func(b *testing.B) {
+		for b.Loop() {
+			c.Find("test")
+		}
+	})
+}
+
+// pathSource returns a realistic list of n file paths.
+func pathSource(n int) []string {
+	dirs := []string{"src", "internal", "pkg", "cmd", "vendor", "node_modules", "test", "docs", "api", "web"}
+	subs := []string{"server", "client", "config", "handlers", "models", "utils", "auth", "storage", "cache", "events"}
+	names := []string{"main", "handler", "config_loader", "router", "user_service", "Index", "README", "middleware", "db", "logger"}
+	exts := []string{".go", ".ts", ".js", ".md", ".json", ".yaml", ".py", ".css"}
+
+	source := make([]string, n)
+	seed := uint32(42)
+	next := func(m int) int {
+		seed = seed*1664525 + 1013904223
+		return int(seed>>16) % m
+	}
+	for i := range source {
+		source[i] = fmt.Sprintf("%s/%s/%s/%s%d%s", dirs[next(len(dirs))], subs[next(len(subs))],
+			subs[next(len(subs))], names[next(len(names))], next(100), exts[next(len(exts))])
+	}
+	return source
+}
+
+func BenchmarkCorpusPrefilter(b *testing.B) {
+	source := pathSource(100000)
+	c := NewCorpus(source)
+
+	for _, q := range []string{"usrsvc", "cfgldr.yaml", "zq", "main.go"} {
+		b.Run("Find "+q, func(b *testing.B) {
+			for b.Loop() {
+				Find(q, source)
+			}
+		})
+
+		b.Run("Corpus "+q, func(b *testing.B) {
+			for b.Loop() {
+				c.Find(q)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package fuzzy
+
+import "time"
+
+// Result is the result of a search that can stop before scanning the whole source (see DeadlineFind).
+type Result struct {
+	Matches  []Match // the matches found in the scanned lines, in the same order as Find
+	Partial  bool    // true if the search stopped before scanning the whole source
+	Coverage float64 // the fraction of the source scanned, from 0 to 1
+}
+
+// newResult creates the result of a search that scanned n lines of a source of size lines.
+func newResult(m []Match, n, size int) Result {
+	r := Result{Matches: m, Partial: n < size, Coverage: 1}
+	if size > 0 {
+		r.Coverage = float64(n) / float64(size)
+	}
+	return r
+}
+
+// DeadlineFind acts the same as ChunkFind, but it stops at the deadline and returns the matches found
+// so far, e.g. to keep a type-ahead search within the time budget of a keystroke:
+//
+//	r := DeadlineFind(query, source, time.Now().Add(16*time.Millisecond))
+//
+// The goroutines check the deadline before taking every batch of lines (see WithBatchSize), so the search
+// returns within about a batch of the deadline, and always after all its goroutines have stopped.
+// When the deadline is hit the result is marked as partial, with the fraction of the source scanned:
+// the matches come from the batches that were completed, so they aren't necessarily the first lines of the source.
+// If the source is too small to be parallelized, the search runs on the calling goroutine.
+func DeadlineFind(query string, source []string, deadline time.Time, opts ...Option) Result {
+	o := newOptions(opts)
+	q := newQuery(query, &o)
+	return chunkSearch(&q, source, standard, &o, deadline)
+}
+
+// DeadlineLevenshteinFind acts the same as ChunkLevenshteinFind, but it stops at the deadline
+// and returns the matches found so far (see DeadlineFind).
+func DeadlineLevenshteinFind(query string, source []string, deadline time.Time, opts ...Option) Result {
+	o := newOptions(opts)
+	q := newQuery(query, &o)
+	return chunkSearch(&q, source, levenshtein, &o, deadline)
+}
@@ -0,0 +1,125 @@
+package fuzzy
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeadlineFind(t *testing.T) {
+	source := make([]string, 1000)
+	for i := range source {
+		source[i] = fmt.Sprintf("test%d", i)
+	}
+
+	pool := NewPool(3)
+	defer pool.Close()
+
+	testCases := []struct {
+		name string
+		opts []Option
+	}{
+		{name: "Sequential", opts: []Option{WithWorkers(1)}},
+		{name: "Parallel", opts: []Option{WithWorkers(4), WithMinChunkSize(10)}},
+		{name: "Pool", opts: []Option{WithPool(pool), WithMinChunkSize(10)}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			deadline := time.Now().Add(time.Hour)
+			for _, q := range []string{"test1", "t9", "tset"} {
+				r := DeadlineFind(q, source, deadline, tc.opts...)
+				if expected := Find(q, source); !reflect.DeepEqual(r, Result{Matches: expected, Coverage: 1}) {
+					t.Errorf("DeadlineFind(%q): expected %d complete matches, got %d (partial %t, coverage %f)", q, len(expected), len(r.Matches), r.Partial, r.Coverage)
+				}
+
+				r = DeadlineLevenshteinFind(q, source, deadline, tc.opts...)
+				if expected := LevenshteinFind(q, source); !reflect.DeepEqual(r, Result{Matches: expected, Coverage: 1}) {
+					t.Errorf("DeadlineLevenshteinFind(%q): expected %d complete matches, got %d (partial %t, coverage %f)", q, len(expected), len(r.Matches), r.Partial, r.Coverage)
+				}
+			}
+
+			r := DeadlineFind("test", source, time.Now().Add(-time.Second), tc.opts...)
+			if !r.Partial || r.Coverage != 0 || len(r.Matches) != 0 {
+				t.Errorf("Expired deadline: expected an empty partial result, got %d matches (partial %t, coverage %f)", len(r.Matches), r.Partial, r.Coverage)
+			}
+		})
+	}
+
+	t.Run("Empty source", func(t *testing.T) {
+		r := DeadlineFind("test", nil, time.Now().Add(-time.Second))
+		if r.Partial || r.Coverage != 1 {
+			t.Errorf("Expected a complete result, got partial %t and coverage %f", r.Partial, r.Coverage)
+		}
+	})
+}
+
+func TestDeadlineFindBusyPool(t *testing.T) {
+	source := make([]string, 1000)
+	for i := range source {
+		source[i] = fmt.Sprintf("test%d", i)
+	}
+
+	// every goroutine of the pool is busy until the end of the test
+	pool := NewPool(2)
+	release := make(chan struct{})
+	defer pool.Close()
+	defer close(release)
+	for range pool.Workers() {
+		for !pool.tryRun(func() { <-release }) {
+			runtime.Gosched()
+		}
+	}
+
+	start := time.Now()
+	r := DeadlineFind("test1", source, start.Add(time.Hour), WithPool(pool), WithMinChunkSize(10))
+	elapsed := time.Since(start)
+
+	// the calling goroutine scans the whole source on its own
+	if expected := Find("test1", source); !reflect.DeepEqual(r, Result{Matches: expected, Coverage: 1}) {
+		t.Errorf("Expected %d complete matches, got %d (partial %t, coverage %f)", len(expected), len(r.Matches), r.Partial, r.Coverage)
+	}
+	if elapsed > 5*time.Second {
+		t.Errorf("Expected the search not to wait for the busy pool, got %v", elapsed)
+	}
+}
+
+func TestDeadlineFindPartial(t *testing.T) {
+	// the lines share the same backing string, so the source is cheap to build but slow to search
+	long := strings.Repeat("at github.com/user/project/pkg.function(0x1234) ", 40)
+	source := make([]string, 100000)
+	for i := range source {
+		source[i] = long[i%10:]
+	}
+
+	for _, workers := range []int{1, 4} {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			start := time.Now()
+			r := DeadlineLevenshteinFind("githbu", source, start.Add(5*time.Millisecond), WithWorkers(workers), WithMinChunkSize(10))
+			elapsed := time.Since(start)
+
+			// the source takes far longer than 5ms to search, but on a loaded machine the goroutines
+			// can start after the deadline, so nothing may have been scanned
+			if !r.Partial || r.Coverage < 0 || r.Coverage >= 1 {
+				t.Fatalf("Expected a partial result, got partial %t and coverage %f", r.Partial, r.Coverage)
+			}
+			if r.Coverage == 0 && len(r.Matches) != 0 {
+				t.Errorf("Expected no matches without scanned lines, got %d", len(r.Matches))
+			}
+			if elapsed > 5*time.Second {
+				t.Errorf("Expected the search to stop about 5ms after the start, got %v", elapsed)
+			}
+			if covered := int(r.Coverage * float64(len(source))); len(r.Matches) > covered {
+				t.Errorf("Expected at most %d matches from the scanned lines, got %d", covered, len(r.Matches))
+			}
+			for i := 1; i < len(r.Matches); i++ {
+				if r.Matches[i].Position <= r.Matches[i-1].Position {
+					t.Fatalf("Expected the matches in the order of the source, got %d after %d", r.Matches[i].Position, r.Matches[i-1].Position)
+				}
+			}
+		})
+	}
+}
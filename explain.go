@@ -0,0 +1,313 @@
+package fuzzy
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Path describes which branch of the standard algorithm produced the score.
+type Path int
+
+const (
+	// PathFiltered means the line was rejected by one of the filters.
+	PathFiltered Path = iota
+	// PathTooShort means the normalized line is shorter than the query.
+	PathTooShort
+	// PathExact means the query is equal to the normalized line (or empty).
+	PathExact
+	// PathSubstring means the query is a substring of the normalized line.
+	PathSubstring
+	// PathFuzzy means every rune of the query was found in order in the normalized line.
+	PathFuzzy
+	// PathNoMatch means at least one rune of the query wasn't found in the normalized line.
+	PathNoMatch
+	// PathTerms means every term of the query was matched on its own (e.g. with SyntaxFZF), see Explanation.Terms.
+	PathTerms
+)
+
+// String returns the name of the path.
+func (p Path) String() string {
+	switch p {
+	case PathFiltered:
+		return "filtered"
+	case PathTooShort:
+		return "too short"
+	case PathExact:
+		return "exact"
+	case PathSubstring:
+		return "substring"
+	case PathFuzzy:
+		return "fuzzy"
+	case PathNoMatch:
+		return "no match"
+	case PathTerms:
+		return "terms"
+	}
+	return "unknown"
+}
+
+// FilterResult reports how a single filter of the query behaved against the source.
+type FilterResult struct {
+	Filter        string // the filter as written in the query (e.g. "!*foo")
+	CaseSensitive bool   // whether the filter is case sensitive (see WithCase)
+	Matched       bool   // whether the filter was satisfied (after the ! negation)
+	Removed       string // the text removed from the line by the filter, if any
+}
+
+// TermResult reports how a single term of the query behaved against the source (e.g. with SyntaxFZF).
+type TermResult struct {
+	Term          string // the term as written in the query (e.g. "'foo")
+	CaseSensitive bool   // whether the term is case sensitive (see WithCase)
+	Matched       bool   // whether the term was satisfied (after the ! inversion)
+	Score         int    // the score of the term, -1 if it wasn't satisfied
+}
+
+// Explanation is a structured breakdown of how MatchScore built its score.
+type Explanation struct {
+	Query         string         // the query text actually scored (filters removed)
+	CaseSensitive bool           // true if the text of the query is case sensitive (for the queries of terms, if every term is)
+	Filters       []FilterResult // the filters evaluated, in order (evaluation stops at the first failure)
+	Terms         []TermResult   // the terms evaluated, for the queries whose terms are matched on their own
+	Normalized    string         // the line actually scored (filters applied, lowercased, whitespace removed)
+	Path          Path           // the branch of the algorithm that produced the score
+	Start         int            // the byte offset in Normalized where the match starts (-1 if there is no match)
+	Gaps          []int          // for fuzzy matches, the bytes skipped before each query rune after the first
+	Positions     []int          // the byte offsets in the source of the runes matched by the text or the terms (e.g. to highlight them)
+	Score         int            // the final score, equal to MatchScore(query, source)
+}
+
+// Explain returns a breakdown of the score that MatchScore would give to the source for the query.
+// It is meant for debugging the ranking (e.g. "why is this result above that one?")
+// and it is slower than MatchScore, so it shouldn't be used in hot paths.
+// The options about the syntax of the query (e.g. WithOperators) apply, the others are ignored.
+// With FoldPinyin, if the initials of the pinyin of the line score better, the breakdown is the one of the initials
+// (without the filters, which are matched on the pinyin).
+func Explain(queryValue, source string, opts ...Option) Explanation {
+	o := newOptions(opts)
+	p := newQuery(queryValue, &o)
+	return explainQuery(&p, source)
+}
+
+// explainQuery returns the breakdown of the score of the source for the parsed query, or for its initials
+// if they score better (see Explain).
+func explainQuery(p *query, source string) Explanation {
+	e := explain(p, source)
+	if p.initials != nil && e.Path != PathFiltered && hasHan(source) {
+		if i := explain(p.initials, source); i.Score >= 0 && (e.Score < 0 || i.Score < e.Score) {
+			return i
+		}
+	}
+	return e
+}
+
+// explain returns the breakdown of the score of the source for the parsed query.
+func explain(p *query, source string) Explanation {
+	q, f := p.text, p.filters
+	e := Explanation{
+		Query:         q,
+		CaseSensitive: p.upper,
+		Filters:       make([]FilterResult, 0, len(f)),
+		Start:         -1,
+		Score:         -1,
+	}
+
+	// the line keeps its case through the filters if a part of the query is case sensitive.
+	// at has the offset in the source of every byte of the line, to report the positions
+	at := make([]int, len(source))
+	for i := range at {
+		at[i] = i
+	}
+	s, at := p.syntax.normalizeAt(source, at, !p.keep)
+
+	for _, fv := range f {
+		var found bool
+		var removed string
+		s, found, removed = fv.applyAt(s, &at)
+		e.Filters = append(e.Filters, FilterResult{Filter: fv.raw, CaseSensitive: fv.sensitive, Matched: found, Removed: removed})
+		if !found {
+			e.Path = PathFiltered
+			return e
+		}
+	}
+
+	if p.terms != nil {
+		if len(f) > 0 {
+			s, at = removeWhitespaceAt(s, at)
+			e.Normalized = s
+		}
+		lower, lowerAt := s, at
+		if p.keep && !p.upper {
+			lower, lowerAt = p.syntax.normalizeAt(s, at, true)
+		}
+		return explainTerms(p, [2]string{lower, s}, [2][]int{lowerAt, at}, e)
+	}
+
+	s, at = removeWhitespaceAt(s, at)
+	if p.keep && !p.upper {
+		s, at = p.syntax.normalizeAt(s, at, true)
+	}
+	e.Normalized = s
+	ql, sl := len(q), len(s)
+
+	switch {
+	case sl < ql:
+		e.Path = PathTooShort
+		return e
+	case q == s, q == "":
+		e.Path, e.Start, e.Score = PathExact, 0, 0
+		e.Positions = positions(q, 0, nil, at)
+		return e
+	case strings.Contains(s, q):
+		e.Path, e.Start, e.Score = PathSubstring, strings.Index(s, q), sl-ql
+		e.Positions = positions(q, e.Start, nil, at)
+		return e
+	}
+
+	start, gaps, ok := fuzzyGaps(q, s)
+	if !ok {
+		e.Path = PathNoMatch
+		return e
+	}
+
+	distance := 0
+	for _, g := range gaps {
+		distance += g
+	}
+	e.Path, e.Start, e.Gaps, e.Score = PathFuzzy, start, gaps, sl-ql+distance
+	e.Positions = positions(q, e.Start, e.Gaps, at)
+	return e
+}
+
+// fuzzyGaps finds the runes of the query in order in the line, as the fuzzy match of the standard algorithm does.
+// It returns the offset of the first rune and the bytes skipped before each of the others.
+func fuzzyGaps(q, s string) (int, []int, bool) {
+	start, gaps := 0, make([]int, 0, utf8.RuneCountInString(q))
+Outer:
+	for index, qr := range q {
+		for i, sr := range s {
+			if qr == sr {
+				s = s[i+utf8.RuneLen(sr):]
+				if index > 0 {
+					gaps = append(gaps, i)
+				} else {
+					start = i
+				}
+				continue Outer
+			}
+		}
+		return -1, nil, false
+	}
+	return start, gaps, true
+}
+
+// positions returns the offsets in the source of the runes of the query matched in the normalized line,
+// given the start of the match, the gaps between the runes (nil for a substring) and the offsets of the line.
+// A rune of the source is reported once, even if its folding matched more runes (e.g. "ß" for "ss").
+func positions(q string, start int, gaps []int, at []int) []int {
+	p := make([]int, 0, utf8.RuneCountInString(q))
+	i := start
+	for index, qr := range q {
+		if index > 0 && gaps != nil {
+			i += gaps[0]
+			gaps = gaps[1:]
+		}
+		if n := at[i]; len(p) == 0 || p[len(p)-1] != n {
+			p = append(p, n)
+		}
+		i += utf8.RuneLen(qr)
+	}
+	return p
+}
+
+// removeWhitespaceAt is the version of removeWhitespace that keeps the offsets of the bytes (see normalizeAt).
+func removeWhitespaceAt(s string, at []int) (string, []int) {
+	b := make([]byte, 0, len(s))
+	offsets := make([]int, 0, len(s))
+	for i, r := range s {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		n := len(b)
+		b = utf8.AppendRune(b, r)
+		for range len(b) - n {
+			offsets = append(offsets, at[i])
+		}
+	}
+	return string(b), offsets
+}
+
+// explainTerms fills the explanation of a query of terms, given the line (after the filters) lowercased and as is,
+// with their offsets, with the score of every term. The path is PathFiltered if a group of terms isn't satisfied,
+// PathTerms otherwise. The positions are the ones of the best term of every group.
+func explainTerms(q *query, line [2]string, at [2][]int, e Explanation) Explanation {
+	l := termLine{line: line}
+	sc := getScratch()
+	defer putScratch(sc)
+
+	e.Path, e.Score = PathTerms, 0
+	for _, group := range q.terms {
+		best, bestTerm := -1, -1
+		for i := range group {
+			score := group[i].score(standard, &l, sc)
+			e.Terms = append(e.Terms, TermResult{Term: group[i].raw, CaseSensitive: group[i].upper, Matched: score >= 0, Score: score})
+			if score >= 0 && (best < 0 || score < best) {
+				best, bestTerm = score, i
+			}
+		}
+		switch {
+		case best >= 0:
+			e.Score += best
+			e.Positions = append(e.Positions, termPositions(&group[bestTerm], line, at)...)
+		case group[0].optional:
+			e.Score += 2*len(line[1]) + 1
+		default:
+			e.Path, e.Score, e.Positions = PathFiltered, -1, nil
+			return e
+		}
+	}
+
+	slices.Sort(e.Positions)
+	e.Positions = slices.Compact(e.Positions)
+	return e
+}
+
+// termPositions returns the offsets in the source of the runes matched by the term, given the line lowercased
+// and as is with their offsets. The inverse terms match nothing.
+func termPositions(t *term, line [2]string, at [2][]int) []int {
+	c := 0
+	if t.upper {
+		c = 1
+	}
+	s, offsets := line[c], at[c]
+
+	i := -1
+	switch t.kind {
+	case termFuzzy:
+		if !t.inverse {
+			s, offsets = removeWhitespaceAt(s, offsets)
+			if i = strings.Index(s, t.value); i < 0 {
+				start, gaps, ok := fuzzyGaps(t.value, s)
+				if !ok {
+					return nil
+				}
+				return positions(t.value, start, gaps, offsets)
+			}
+		}
+	case termExact:
+		i = strings.Index(s, t.value)
+	case termBoundary:
+		i = indexWord(s, t.value)
+	case termPrefix:
+		i = len(s) - len(trimFor(s, t.value, strings.TrimLeftFunc))
+	case termSuffix:
+		i = len(trimFor(s, t.value, strings.TrimRightFunc)) - len(t.value)
+	case termEqual:
+		i = len(s) - len(strings.TrimLeftFunc(s, unicode.IsSpace))
+	}
+	if i < 0 || t.inverse {
+		return nil
+	}
+	return positions(t.value, i, nil, offsets)
+}
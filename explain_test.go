@@ -0,0 +1,198 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		source   string
+		expected Explanation
+	}{
+		{
+			name:   "Exact match",
+			query:  "test",
+			source: "Test",
+			expected: Explanation{
+				Query:      "test",
+				Filters:    []FilterResult{},
+				Normalized: "test",
+				Path:       PathExact,
+				Start:      0,
+				Positions:  []int{0, 1, 2, 3},
+				Score:      0,
+			},
+		},
+		{
+			name:   "Substring match",
+			query:  "test",
+			source: "my testing",
+			expected: Explanation{
+				Query:      "test",
+				Filters:    []FilterResult{},
+				Normalized: "mytesting",
+				Path:       PathSubstring,
+				Start:      2,
+				Positions:  []int{3, 4, 5, 6},
+				Score:      5,
+			},
+		},
+		{
+			name:   "Fuzzy match",
+			query:  "ca",
+			source: "clap",
+			expected: Explanation{
+				Query:      "ca",
+				Filters:    []FilterResult{},
+				Normalized: "clap",
+				Path:       PathFuzzy,
+				Start:      0,
+				Gaps:       []int{1},
+				Positions:  []int{0, 2},
+				Score:      3,
+			},
+		},
+		{
+			name:   "No match",
+			query:  "xyz",
+			source: "test",
+			expected: Explanation{
+				Query:      "xyz",
+				Filters:    []FilterResult{},
+				Normalized: "test",
+				Path:       PathNoMatch,
+				Start:      -1,
+				Score:      -1,
+			},
+		},
+		{
+			name:   "Too short",
+			query:  "testing",
+			source: "test",
+			expected: Explanation{
+				Query:      "testing",
+				Filters:    []FilterResult{},
+				Normalized: "test",
+				Path:       PathTooShort,
+				Start:      -1,
+				Score:      -1,
+			},
+		},
+		{
+			name:   "Case sensitive",
+			query:  "Test",
+			source: "a Test",
+			expected: Explanation{
+				Query:         "Test",
+				CaseSensitive: true,
+				Filters:       []FilterResult{},
+				Normalized:    "aTest",
+				Path:          PathSubstring,
+				Start:         1,
+				Positions:     []int{2, 3, 4, 5},
+				Score:         1,
+			},
+		},
+		{
+			name:   "Filters removing text",
+			query:  "^hello *world $test big",
+			source: "hello big world test",
+			expected: Explanation{
+				Query: "big",
+				Filters: []FilterResult{
+					{Filter: "^hello", Matched: true, Removed: "hello"},
+					{Filter: "*world", Matched: true, Removed: "world"},
+					{Filter: "$test", Matched: true, Removed: "test"},
+				},
+				Normalized: "big",
+				Path:       PathExact,
+				Start:      0,
+				Positions:  []int{6, 7, 8},
+				Score:      0,
+			},
+		},
+		{
+			name:   "Filter rejecting the line",
+			query:  "!*another *xyz test",
+			source: "another test",
+			expected: Explanation{
+				Query: "test",
+				Filters: []FilterResult{
+					{Filter: "!*another", Matched: false},
+				},
+				Path:  PathFiltered,
+				Start: -1,
+				Score: -1,
+			},
+		},
+		{
+			name:   "Negated word rejecting the line",
+			query:  "!another test",
+			source: "Another test",
+			expected: Explanation{
+				Query: "test",
+				Filters: []FilterResult{
+					{Filter: "!another", Matched: false},
+				},
+				Path:  PathFiltered,
+				Start: -1,
+				Score: -1,
+			},
+		},
+		{
+			name:   "Regex filter",
+			query:  "?\\d+ ab",
+			source: "a1b",
+			expected: Explanation{
+				Query: "ab",
+				Filters: []FilterResult{
+					{Filter: "?\\d+", Matched: true},
+				},
+				Normalized: "a1b",
+				Path:       PathFuzzy,
+				Start:      0,
+				Gaps:       []int{1},
+				Positions:  []int{0, 2},
+				Score:      2,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := Explain(tc.query, tc.source)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, result)
+			}
+			if score := MatchScore(tc.query, tc.source); result.Score != score {
+				t.Errorf("Expected score %d (MatchScore), got %d", score, result.Score)
+			}
+		})
+	}
+}
+
+func TestPathString(t *testing.T) {
+	testCases := []struct {
+		input    Path
+		expected string
+	}{
+		{PathFiltered, "filtered"},
+		{PathTooShort, "too short"},
+		{PathExact, "exact"},
+		{PathSubstring, "substring"},
+		{PathFuzzy, "fuzzy"},
+		{PathNoMatch, "no match"},
+		{Path(-1), "unknown"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			if result := tc.input.String(); result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
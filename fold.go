@@ -0,0 +1,183 @@
+package fuzzy
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Folding is a set of foldings applied to the query and to the lines before they are matched (see WithFolding).
+// The foldings are bit flags, so they can be combined (e.g. FoldMarks|FoldCase).
+type Folding uint
+
+const (
+	// FoldMarks removes the diacritics (the combining marks of the canonical decomposition, NFD),
+	// so "citta" matches "città", and strips the letters with a stroke ("ø" is "o", "ł" is "l").
+	FoldMarks Folding = 1 << iota
+	// FoldCase lowercases with the full case folding instead of unicode.ToLower, so "strasse" matches "Straße".
+	FoldCase
+	// FoldCompat replaces the compatibility forms (NFKC) and expands the ligatures,
+	// so "file" matches "ﬁle", "2" matches "²" and "aeon" matches "æon".
+	FoldCompat
+	// FoldTurkish lowercases "I" to "ı" and "İ" to "i", as in Turkish and Azerbaijani.
+	FoldTurkish
+	// FoldWidth replaces the full-width and half-width forms, so "ABC" matches "ＡＢＣ" and "カ" matches "ｶ"
+	// (a half-width katakana with its sound mark is a single kana, "ｶﾞ" is "ガ").
+	FoldWidth
+	// FoldKana replaces the katakana with the hiragana, so "かな" matches "カナ".
+	FoldKana
+	// FoldPinyin transliterates the Han characters to their pinyin, without the tones, so "beijing" matches "北京".
+	// Every character has a single reading, the one of the table, and the context isn't considered: the characters
+	// with more readings match only that one (e.g. "长" is "zhang" and "行" is "xing", so "changcheng" doesn't
+	// match "长城" and "yinhang" doesn't match "银行", while a Han query matches them).
+	// The lines with Han characters are matched on the initials of the syllables too, so "bj" matches "北京".
+	FoldPinyin
+	// FoldRomaji transliterates the kana to their romaji (Hepburn), so "tokyo" matches "とうきょう" and "トーキョー".
+	FoldRomaji
+	// FoldCyrillic transliterates the Cyrillic letters to Latin (BGN/PCGN, without the apostrophes of the signs),
+	// so "dmitriy" matches "Дмитрий", and the other way round.
+	FoldCyrillic
+	// FoldGreek transliterates the Greek letters to Latin (ELOT 743, without the accents), so "athina" matches "Αθήνα".
+	FoldGreek
+
+	// FoldAll is every folding, except the locale ones (FoldTurkish) and the transliterations
+	// (FoldPinyin, FoldRomaji, FoldCyrillic and FoldGreek).
+	FoldAll = FoldMarks | FoldCase | FoldCompat | FoldWidth | FoldKana
+
+	// foldInitials transliterates the Han characters to the initials of their pinyin (see query.initials).
+	foldInitials Folding = 1 << 31
+)
+
+// ligatures are the ligatures that aren't compatibility forms, so they aren't in the compatibility table.
+var ligatures = map[rune]string{
+	'æ': "ae", 'Æ': "AE", 'œ': "oe", 'Œ': "OE", 'ĳ': "ij", 'Ĳ': "IJ",
+}
+
+// strokes are the letters with a stroke or a bar, that have no canonical decomposition.
+var strokes = map[rune]rune{
+	'ø': 'o', 'Ø': 'O', 'ł': 'l', 'Ł': 'L', 'đ': 'd', 'Đ': 'D', 'ħ': 'h', 'Ħ': 'H',
+	'ŧ': 't', 'Ŧ': 'T', 'ƀ': 'b', 'Ƀ': 'B', 'ɨ': 'i', 'Ɨ': 'I', 'ƶ': 'z', 'Ƶ': 'Z',
+}
+
+// normalize folds the string with the foldings of the syntax, lowercasing it if lower is true.
+// Without foldings it's the same as strings.ToLower (or nothing), and so are the ASCII strings,
+// except with FoldTurkish.
+func (s *syntax) normalize(str string, lower bool) string {
+	str, _ = s.transliterate(str, nil)
+	if s.folding == 0 || s.folding&FoldTurkish == 0 && isASCII(str) {
+		if lower {
+			return strings.ToLower(str)
+		}
+		return str
+	}
+
+	b := make([]byte, 0, len(str))
+	for _, r := range str {
+		b = appendFolded(b, r, s.folding, lower)
+	}
+	return string(b)
+}
+
+// normalizeAt is the version of normalize that keeps track of the positions: at has the byte offset in the source
+// of every byte of the string, and the returned offsets are the ones of every byte of the normalized string
+// (a byte produced by the folding of a rune has the offset of the rune).
+func (s *syntax) normalizeAt(str string, at []int, lower bool) (string, []int) {
+	str, at = s.transliterate(str, at)
+	if s.folding == 0 && !lower {
+		return str, at
+	}
+
+	b := make([]byte, 0, len(str))
+	offsets := make([]int, 0, len(str))
+	for i, r := range str {
+		n := len(b)
+		if s.folding == 0 {
+			b = utf8.AppendRune(b, unicode.ToLower(r))
+		} else {
+			b = appendFolded(b, r, s.folding, lower)
+		}
+		for range len(b) - n {
+			offsets = append(offsets, at[i])
+		}
+	}
+	return string(b), offsets
+}
+
+// prepare folds a value of the query (the text, a filter or a term) and decides its case sensitivity
+// on the value as written: the value is lowercased if it isn't case sensitive.
+func (s *syntax) prepare(value string) (string, bool) {
+	sensitive := s.sensitive(value)
+	return s.normalize(value, !sensitive), sensitive
+}
+
+// appendFolded appends the folding of the rune to b: first its narrow form and its hiragana, then its compatibility form,
+// then its lowercase form if lower is true, and last its base letter without the marks.
+// A sound mark after a kana is composed with the kana already in b.
+func appendFolded(b []byte, r rune, f Folding, lower bool) []byte {
+	if f&FoldWidth != 0 {
+		if c, ok := appendVoiced(b, r); ok {
+			return c
+		}
+		r = narrow(r)
+	}
+	if f&FoldKana != 0 {
+		r = hiragana(r)
+	}
+	if f&FoldCompat != 0 {
+		c, ok := compatibility[r]
+		if !ok {
+			c, ok = ligatures[r]
+		}
+		if ok {
+			for _, cr := range c {
+				b = appendCased(b, cr, f, lower)
+			}
+			return b
+		}
+	}
+	return appendCased(b, r, f, lower)
+}
+
+// appendCased appends the rune to b, lowercased if lower is true and without the marks if f has FoldMarks.
+func appendCased(b []byte, r rune, f Folding, lower bool) []byte {
+	if lower {
+		switch {
+		case f&FoldTurkish != 0 && r == 'I':
+			r = 'ı'
+		case f&FoldTurkish != 0 && r == 'İ':
+			r = 'i'
+		case f&FoldCase != 0:
+			if c, ok := caseFolding[r]; ok {
+				for _, cr := range c {
+					b = appendUnmarked(b, cr, f)
+				}
+				return b
+			}
+			r = unicode.ToLower(r)
+		default:
+			r = unicode.ToLower(r)
+		}
+	}
+	return appendUnmarked(b, r, f)
+}
+
+// appendUnmarked appends the rune to b, without the marks if f has FoldMarks.
+func appendUnmarked(b []byte, r rune, f Folding) []byte {
+	if f&FoldMarks != 0 {
+		if d, ok := decompositions[r]; ok {
+			for _, dr := range d {
+				if !unicode.Is(unicode.Mn, dr) {
+					b = utf8.AppendRune(b, dr)
+				}
+			}
+			return b
+		}
+		if unicode.Is(unicode.Mn, r) {
+			return b
+		}
+		if s, ok := strokes[r]; ok {
+			r = s
+		}
+	}
+	return utf8.AppendRune(b, r)
+}
@@ -0,0 +1,1521 @@
+package fuzzy
+
+// The folding tables are derived from the Unicode Character Database (UnicodeData.txt and CaseFolding.txt).
+// They cover the Latin, Greek and Cyrillic scripts and the compatibility forms used in their texts:
+// the CJK forms are folded separately.
+
+// decompositions are the canonical decompositions (NFD) of the precomposed letters, e.g. "à" is "a" and U+0300.
+var decompositions = map[rune]string{
+	'À': "A\u0300",             // latin capital letter a with grave
+	'Á': "A\u0301",             // latin capital letter a with acute
+	'Â': "A\u0302",             // latin capital letter a with circumflex
+	'Ã': "A\u0303",             // latin capital letter a with tilde
+	'Ä': "A\u0308",             // latin capital letter a with diaeresis
+	'Å': "A\u030A",             // latin capital letter a with ring above
+	'Ç': "C\u0327",             // latin capital letter c with cedilla
+	'È': "E\u0300",             // latin capital letter e with grave
+	'É': "E\u0301",             // latin capital letter e with acute
+	'Ê': "E\u0302",             // latin capital letter e with circumflex
+	'Ë': "E\u0308",             // latin capital letter e with diaeresis
+	'Ì': "I\u0300",             // latin capital letter i with grave
+	'Í': "I\u0301",             // latin capital letter i with acute
+	'Î': "I\u0302",             // latin capital letter i with circumflex
+	'Ï': "I\u0308",             // latin capital letter i with diaeresis
+	'Ñ': "N\u0303",             // latin capital letter n with tilde
+	'Ò': "O\u0300",             // latin capital letter o with grave
+	'Ó': "O\u0301",             // latin capital letter o with acute
+	'Ô': "O\u0302",             // latin capital letter o with circumflex
+	'Õ': "O\u0303",             // latin capital letter o with tilde
+	'Ö': "O\u0308",             // latin capital letter o with diaeresis
+	'Ù': "U\u0300",             // latin capital letter u with grave
+	'Ú': "U\u0301",             // latin capital letter u with acute
+	'Û': "U\u0302",             // latin capital letter u with circumflex
+	'Ü': "U\u0308",             // latin capital letter u with diaeresis
+	'Ý': "Y\u0301",             // latin capital letter y with acute
+	'à': "a\u0300",             // latin small letter a with grave
+	'á': "a\u0301",             // latin small letter a with acute
+	'â': "a\u0302",             // latin small letter a with circumflex
+	'ã': "a\u0303",             // latin small letter a with tilde
+	'ä': "a\u0308",             // latin small letter a with diaeresis
+	'å': "a\u030A",             // latin small letter a with ring above
+	'ç': "c\u0327",             // latin small letter c with cedilla
+	'è': "e\u0300",             // latin small letter e with grave
+	'é': "e\u0301",             // latin small letter e with acute
+	'ê': "e\u0302",             // latin small letter e with circumflex
+	'ë': "e\u0308",             // latin small letter e with diaeresis
+	'ì': "i\u0300",             // latin small letter i with grave
+	'í': "i\u0301",             // latin small letter i with acute
+	'î': "i\u0302",             // latin small letter i with circumflex
+	'ï': "i\u0308",             // latin small letter i with diaeresis
+	'ñ': "n\u0303",             // latin small letter n with tilde
+	'ò': "o\u0300",             // latin small letter o with grave
+	'ó': "o\u0301",             // latin small letter o with acute
+	'ô': "o\u0302",             // latin small letter o with circumflex
+	'õ': "o\u0303",             // latin small letter o with tilde
+	'ö': "o\u0308",             // latin small letter o with diaeresis
+	'ù': "u\u0300",             // latin small letter u with grave
+	'ú': "u\u0301",             // latin small letter u with acute
+	'û': "u\u0302",             // latin small letter u with circumflex
+	'ü': "u\u0308",             // latin small letter u with diaeresis
+	'ý': "y\u0301",             // latin small letter y with acute
+	'ÿ': "y\u0308",             // latin small letter y with diaeresis
+	'Ā': "A\u0304",             // latin capital letter a with macron
+	'ā': "a\u0304",             // latin small letter a with macron
+	'Ă': "A\u0306",             // latin capital letter a with breve
+	'ă': "a\u0306",             // latin small letter a with breve
+	'Ą': "A\u0328",             // latin capital letter a with ogonek
+	'ą': "a\u0328",             // latin small letter a with ogonek
+	'Ć': "C\u0301",             // latin capital letter c with acute
+	'ć': "c\u0301",             // latin small letter c with acute
+	'Ĉ': "C\u0302",             // latin capital letter c with circumflex
+	'ĉ': "c\u0302",             // latin small letter c with circumflex
+	'Ċ': "C\u0307",             // latin capital letter c with dot above
+	'ċ': "c\u0307",             // latin small letter c with dot above
+	'Č': "C\u030C",             // latin capital letter c with caron
+	'č': "c\u030C",             // latin small letter c with caron
+	'Ď': "D\u030C",             // latin capital letter d with caron
+	'ď': "d\u030C",             // latin small letter d with caron
+	'Ē': "E\u0304",             // latin capital letter e with macron
+	'ē': "e\u0304",             // latin small letter e with macron
+	'Ĕ': "E\u0306",             // latin capital letter e with breve
+	'ĕ': "e\u0306",             // latin small letter e with breve
+	'Ė': "E\u0307",             // latin capital letter e with dot above
+	'ė': "e\u0307",             // latin small letter e with dot above
+	'Ę': "E\u0328",             // latin capital letter e with ogonek
+	'ę': "e\u0328",             // latin small letter e with ogonek
+	'Ě': "E\u030C",             // latin capital letter e with caron
+	'ě': "e\u030C",             // latin small letter e with caron
+	'Ĝ': "G\u0302",             // latin capital letter g with circumflex
+	'ĝ': "g\u0302",             // latin small letter g with circumflex
+	'Ğ': "G\u0306",             // latin capital letter g with breve
+	'ğ': "g\u0306",             // latin small letter g with breve
+	'Ġ': "G\u0307",             // latin capital letter g with dot above
+	'ġ': "g\u0307",             // latin small letter g with dot above
+	'Ģ': "G\u0327",             // latin capital letter g with cedilla
+	'ģ': "g\u0327",             // latin small letter g with cedilla
+	'Ĥ': "H\u0302",             // latin capital letter h with circumflex
+	'ĥ': "h\u0302",             // latin small letter h with circumflex
+	'Ĩ': "I\u0303",             // latin capital letter i with tilde
+	'ĩ': "i\u0303",             // latin small letter i with tilde
+	'Ī': "I\u0304",             // latin capital letter i with macron
+	'ī': "i\u0304",             // latin small letter i with macron
+	'Ĭ': "I\u0306",             // latin capital letter i with breve
+	'ĭ': "i\u0306",             // latin small letter i with breve
+	'Į': "I\u0328",             // latin capital letter i with ogonek
+	'į': "i\u0328",             // latin small letter i with ogonek
+	'İ': "I\u0307",             // latin capital letter i with dot above
+	'Ĵ': "J\u0302",             // latin capital letter j with circumflex
+	'ĵ': "j\u0302",             // latin small letter j with circumflex
+	'Ķ': "K\u0327",             // latin capital letter k with cedilla
+	'ķ': "k\u0327",             // latin small letter k with cedilla
+	'Ĺ': "L\u0301",             // latin capital letter l with acute
+	'ĺ': "l\u0301",             // latin small letter l with acute
+	'Ļ': "L\u0327",             // latin capital letter l with cedilla
+	'ļ': "l\u0327",             // latin small letter l with cedilla
+	'Ľ': "L\u030C",             // latin capital letter l with caron
+	'ľ': "l\u030C",             // latin small letter l with caron
+	'Ń': "N\u0301",             // latin capital letter n with acute
+	'ń': "n\u0301",             // latin small letter n with acute
+	'Ņ': "N\u0327",             // latin capital letter n with cedilla
+	'ņ': "n\u0327",             // latin small letter n with cedilla
+	'Ň': "N\u030C",             // latin capital letter n with caron
+	'ň': "n\u030C",             // latin small letter n with caron
+	'Ō': "O\u0304",             // latin capital letter o with macron
+	'ō': "o\u0304",             // latin small letter o with macron
+	'Ŏ': "O\u0306",             // latin capital letter o with breve
+	'ŏ': "o\u0306",             // latin small letter o with breve
+	'Ő': "O\u030B",             // latin capital letter o with double acute
+	'ő': "o\u030B",             // latin small letter o with double acute
+	'Ŕ': "R\u0301",             // latin capital letter r with acute
+	'ŕ': "r\u0301",             // latin small letter r with acute
+	'Ŗ': "R\u0327",             // latin capital letter r with cedilla
+	'ŗ': "r\u0327",             // latin small letter r with cedilla
+	'Ř': "R\u030C",             // latin capital letter r with caron
+	'ř': "r\u030C",             // latin small letter r with caron
+	'Ś': "S\u0301",             // latin capital letter s with acute
+	'ś': "s\u0301",             // latin small letter s with acute
+	'Ŝ': "S\u0302",             // latin capital letter s with circumflex
+	'ŝ': "s\u0302",             // latin small letter s with circumflex
+	'Ş': "S\u0327",             // latin capital letter s with cedilla
+	'ş': "s\u0327",             // latin small letter s with cedilla
+	'Š': "S\u030C",             // latin capital letter s with caron
+	'š': "s\u030C",             // latin small letter s with caron
+	'Ţ': "T\u0327",             // latin capital letter t with cedilla
+	'ţ': "t\u0327",             // latin small letter t with cedilla
+	'Ť': "T\u030C",             // latin capital letter t with caron
+	'ť': "t\u030C",             // latin small letter t with caron
+	'Ũ': "U\u0303",             // latin capital letter u with tilde
+	'ũ': "u\u0303",             // latin small letter u with tilde
+	'Ū': "U\u0304",             // latin capital letter u with macron
+	'ū': "u\u0304",             // latin small letter u with macron
+	'Ŭ': "U\u0306",             // latin capital letter u with breve
+	'ŭ': "u\u0306",             // latin small letter u with breve
+	'Ů': "U\u030A",             // latin capital letter u with ring above
+	'ů': "u\u030A",             // latin small letter u with ring above
+	'Ű': "U\u030B",             // latin capital letter u with double acute
+	'ű': "u\u030B",             // latin small letter u with double acute
+	'Ų': "U\u0328",             // latin capital letter u with ogonek
+	'ų': "u\u0328",             // latin small letter u with ogonek
+	'Ŵ': "W\u0302",             // latin capital letter w with circumflex
+	'ŵ': "w\u0302",             // latin small letter w with circumflex
+	'Ŷ': "Y\u0302",             // latin capital letter y with circumflex
+	'ŷ': "y\u0302",             // latin small letter y with circumflex
+	'Ÿ': "Y\u0308",             // latin capital letter y with diaeresis
+	'Ź': "Z\u0301",             // latin capital letter z with acute
+	'ź': "z\u0301",             // latin small letter z with acute
+	'Ż': "Z\u0307",             // latin capital letter z with dot above
+	'ż': "z\u0307",             // latin small letter z with dot above
+	'Ž': "Z\u030C",             // latin capital letter z with caron
+	'ž': "z\u030C",             // latin small letter z with caron
+	'Ơ': "O\u031B",             // latin capital letter o with horn
+	'ơ': "o\u031B",             // latin small letter o with horn
+	'Ư': "U\u031B",             // latin capital letter u with horn
+	'ư': "u\u031B",             // latin small letter u with horn
+	'Ǎ': "A\u030C",             // latin capital letter a with caron
+	'ǎ': "a\u030C",             // latin small letter a with caron
+	'Ǐ': "I\u030C",             // latin capital letter i with caron
+	'ǐ': "i\u030C",             // latin small letter i with caron
+	'Ǒ': "O\u030C",             // latin capital letter o with caron
+	'ǒ': "o\u030C",             // latin small letter o with caron
+	'Ǔ': "U\u030C",             // latin capital letter u with caron
+	'ǔ': "u\u030C",             // latin small letter u with caron
+	'Ǖ': "U\u0308\u0304",       // latin capital letter u with diaeresis and macron
+	'ǖ': "u\u0308\u0304",       // latin small letter u with diaeresis and macron
+	'Ǘ': "U\u0308\u0301",       // latin capital letter u with diaeresis and acute
+	'ǘ': "u\u0308\u0301",       // latin small letter u with diaeresis and acute
+	'Ǚ': "U\u0308\u030C",       // latin capital letter u with diaeresis and caron
+	'ǚ': "u\u0308\u030C",       // latin small letter u with diaeresis and caron
+	'Ǜ': "U\u0308\u0300",       // latin capital letter u with diaeresis and grave
+	'ǜ': "u\u0308\u0300",       // latin small letter u with diaeresis and grave
+	'Ǟ': "A\u0308\u0304",       // latin capital letter a with diaeresis and macron
+	'ǟ': "a\u0308\u0304",       // latin small letter a with diaeresis and macron
+	'Ǡ': "A\u0307\u0304",       // latin capital letter a with dot above and macron
+	'ǡ': "a\u0307\u0304",       // latin small letter a with dot above and macron
+	'Ǣ': "Æ\u0304",             // latin capital letter ae with macron
+	'ǣ': "æ\u0304",             // latin small letter ae with macron
+	'Ǧ': "G\u030C",             // latin capital letter g with caron
+	'ǧ': "g\u030C",             // latin small letter g with caron
+	'Ǩ': "K\u030C",             // latin capital letter k with caron
+	'ǩ': "k\u030C",             // latin small letter k with caron
+	'Ǫ': "O\u0328",             // latin capital letter o with ogonek
+	'ǫ': "o\u0328",             // latin small letter o with ogonek
+	'Ǭ': "O\u0328\u0304",       // latin capital letter o with ogonek and macron
+	'ǭ': "o\u0328\u0304",       // latin small letter o with ogonek and macron
+	'Ǯ': "Ʒ\u030C",             // latin capital letter ezh with caron
+	'ǯ': "ʒ\u030C",             // latin small letter ezh with caron
+	'ǰ': "j\u030C",             // latin small letter j with caron
+	'Ǵ': "G\u0301",             // latin capital letter g with acute
+	'ǵ': "g\u0301",             // latin small letter g with acute
+	'Ǹ': "N\u0300",             // latin capital letter n with grave
+	'ǹ': "n\u0300",             // latin small letter n with grave
+	'Ǻ': "A\u030A\u0301",       // latin capital letter a with ring above and acute
+	'ǻ': "a\u030A\u0301",       // latin small letter a with ring above and acute
+	'Ǽ': "Æ\u0301",             // latin capital letter ae with acute
+	'ǽ': "æ\u0301",             // latin small letter ae with acute
+	'Ǿ': "Ø\u0301",             // latin capital letter o with stroke and acute
+	'ǿ': "ø\u0301",             // latin small letter o with stroke and acute
+	'Ȁ': "A\u030F",             // latin capital letter a with double grave
+	'ȁ': "a\u030F",             // latin small letter a with double grave
+	'Ȃ': "A\u0311",             // latin capital letter a with inverted breve
+	'ȃ': "a\u0311",             // latin small letter a with inverted breve
+	'Ȅ': "E\u030F",             // latin capital letter e with double grave
+	'ȅ': "e\u030F",             // latin small letter e with double grave
+	'Ȇ': "E\u0311",             // latin capital letter e with inverted breve
+	'ȇ': "e\u0311",             // latin small letter e with inverted breve
+	'Ȉ': "I\u030F",             // latin capital letter i with double grave
+	'ȉ': "i\u030F",             // latin small letter i with double grave
+	'Ȋ': "I\u0311",             // latin capital letter i with inverted breve
+	'ȋ': "i\u0311",             // latin small letter i with inverted breve
+	'Ȍ': "O\u030F",             // latin capital letter o with double grave
+	'ȍ': "o\u030F",             // latin small letter o with double grave
+	'Ȏ': "O\u0311",             // latin capital letter o with inverted breve
+	'ȏ': "o\u0311",             // latin small letter o with inverted breve
+	'Ȑ': "R\u030F",             // latin capital letter r with double grave
+	'ȑ': "r\u030F",             // latin small letter r with double grave
+	'Ȓ': "R\u0311",             // latin capital letter r with inverted breve
+	'ȓ': "r\u0311",             // latin small letter r with inverted breve
+	'Ȕ': "U\u030F",             // latin capital letter u with double grave
+	'ȕ': "u\u030F",             // latin small letter u with double grave
+	'Ȗ': "U\u0311",             // latin capital letter u with inverted breve
+	'ȗ': "u\u0311",             // latin small letter u with inverted breve
+	'Ș': "S\u0326",             // latin capital letter s with comma below
+	'ș': "s\u0326",             // latin small letter s with comma below
+	'Ț': "T\u0326",             // latin capital letter t with comma below
+	'ț': "t\u0326",             // latin small letter t with comma below
+	'Ȟ': "H\u030C",             // latin capital letter h with caron
+	'ȟ': "h\u030C",             // latin small letter h with caron
+	'Ȧ': "A\u0307",             // latin capital letter a with dot above
+	'ȧ': "a\u0307",             // latin small letter a with dot above
+	'Ȩ': "E\u0327",             // latin capital letter e with cedilla
+	'ȩ': "e\u0327",             // latin small letter e with cedilla
+	'Ȫ': "O\u0308\u0304",       // latin capital letter o with diaeresis and macron
+	'ȫ': "o\u0308\u0304",       // latin small letter o with diaeresis and macron
+	'Ȭ': "O\u0303\u0304",       // latin capital letter o with tilde and macron
+	'ȭ': "o\u0303\u0304",       // latin small letter o with tilde and macron
+	'Ȯ': "O\u0307",             // latin capital letter o with dot above
+	'ȯ': "o\u0307",             // latin small letter o with dot above
+	'Ȱ': "O\u0307\u0304",       // latin capital letter o with dot above and macron
+	'ȱ': "o\u0307\u0304",       // latin small letter o with dot above and macron
+	'Ȳ': "Y\u0304",             // latin capital letter y with macron
+	'ȳ': "y\u0304",             // latin small letter y with macron
+	'ʹ': "ʹ",                   // greek numeral sign
+	';': ";",                   // greek question mark
+	'΅': "¨\u0301",             // greek dialytika tonos
+	'Ά': "Α\u0301",             // greek capital letter alpha with tonos
+	'·': "·",                   // greek ano teleia
+	'Έ': "Ε\u0301",             // greek capital letter epsilon with tonos
+	'Ή': "Η\u0301",             // greek capital letter eta with tonos
+	'Ί': "Ι\u0301",             // greek capital letter iota with tonos
+	'Ό': "Ο\u0301",             // greek capital letter omicron with tonos
+	'Ύ': "Υ\u0301",             // greek capital letter upsilon with tonos
+	'Ώ': "Ω\u0301",             // greek capital letter omega with tonos
+	'ΐ': "ι\u0308\u0301",       // greek small letter iota with dialytika and tonos
+	'Ϊ': "Ι\u0308",             // greek capital letter iota with dialytika
+	'Ϋ': "Υ\u0308",             // greek capital letter upsilon with dialytika
+	'ά': "α\u0301",             // greek small letter alpha with tonos
+	'έ': "ε\u0301",             // greek small letter epsilon with tonos
+	'ή': "η\u0301",             // greek small letter eta with tonos
+	'ί': "ι\u0301",             // greek small letter iota with tonos
+	'ΰ': "υ\u0308\u0301",       // greek small letter upsilon with dialytika and tonos
+	'ϊ': "ι\u0308",             // greek small letter iota with dialytika
+	'ϋ': "υ\u0308",             // greek small letter upsilon with dialytika
+	'ό': "ο\u0301",             // greek small letter omicron with tonos
+	'ύ': "υ\u0301",             // greek small letter upsilon with tonos
+	'ώ': "ω\u0301",             // greek small letter omega with tonos
+	'ϓ': "ϒ\u0301",             // greek upsilon with acute and hook symbol
+	'ϔ': "ϒ\u0308",             // greek upsilon with diaeresis and hook symbol
+	'Ѐ': "Е\u0300",             // cyrillic capital letter ie with grave
+	'Ё': "Е\u0308",             // cyrillic capital letter io
+	'Ѓ': "Г\u0301",             // cyrillic capital letter gje
+	'Ї': "І\u0308",             // cyrillic capital letter yi
+	'Ќ': "К\u0301",             // cyrillic capital letter kje
+	'Ѝ': "И\u0300",             // cyrillic capital letter i with grave
+	'Ў': "У\u0306",             // cyrillic capital letter short u
+	'Й': "И\u0306",             // cyrillic capital letter short i
+	'й': "и\u0306",             // cyrillic small letter short i
+	'ѐ': "е\u0300",             // cyrillic small letter ie with grave
+	'ё': "е\u0308",             // cyrillic small letter io
+	'ѓ': "г\u0301",             // cyrillic small letter gje
+	'ї': "і\u0308",             // cyrillic small letter yi
+	'ќ': "к\u0301",             // cyrillic small letter kje
+	'ѝ': "и\u0300",             // cyrillic small letter i with grave
+	'ў': "у\u0306",             // cyrillic small letter short u
+	'Ѷ': "Ѵ\u030F",             // cyrillic capital letter izhitsa with double grave accent
+	'ѷ': "ѵ\u030F",             // cyrillic small letter izhitsa with double grave accent
+	'Ӂ': "Ж\u0306",             // cyrillic capital letter zhe with breve
+	'ӂ': "ж\u0306",             // cyrillic small letter zhe with breve
+	'Ӑ': "А\u0306",             // cyrillic capital letter a with breve
+	'ӑ': "а\u0306",             // cyrillic small letter a with breve
+	'Ӓ': "А\u0308",             // cyrillic capital letter a with diaeresis
+	'ӓ': "а\u0308",             // cyrillic small letter a with diaeresis
+	'Ӗ': "Е\u0306",             // cyrillic capital letter ie with breve
+	'ӗ': "е\u0306",             // cyrillic small letter ie with breve
+	'Ӛ': "Ә\u0308",             // cyrillic capital letter schwa with diaeresis
+	'ӛ': "ә\u0308",             // cyrillic small letter schwa with diaeresis
+	'Ӝ': "Ж\u0308",             // cyrillic capital letter zhe with diaeresis
+	'ӝ': "ж\u0308",             // cyrillic small letter zhe with diaeresis
+	'Ӟ': "З\u0308",             // cyrillic capital letter ze with diaeresis
+	'ӟ': "з\u0308",             // cyrillic small letter ze with diaeresis
+	'Ӣ': "И\u0304",             // cyrillic capital letter i with macron
+	'ӣ': "и\u0304",             // cyrillic small letter i with macron
+	'Ӥ': "И\u0308",             // cyrillic capital letter i with diaeresis
+	'ӥ': "и\u0308",             // cyrillic small letter i with diaeresis
+	'Ӧ': "О\u0308",             // cyrillic capital letter o with diaeresis
+	'ӧ': "о\u0308",             // cyrillic small letter o with diaeresis
+	'Ӫ': "Ө\u0308",             // cyrillic capital letter barred o with diaeresis
+	'ӫ': "ө\u0308",             // cyrillic small letter barred o with diaeresis
+	'Ӭ': "Э\u0308",             // cyrillic capital letter e with diaeresis
+	'ӭ': "э\u0308",             // cyrillic small letter e with diaeresis
+	'Ӯ': "У\u0304",             // cyrillic capital letter u with macron
+	'ӯ': "у\u0304",             // cyrillic small letter u with macron
+	'Ӱ': "У\u0308",             // cyrillic capital letter u with diaeresis
+	'ӱ': "у\u0308",             // cyrillic small letter u with diaeresis
+	'Ӳ': "У\u030B",             // cyrillic capital letter u with double acute
+	'ӳ': "у\u030B",             // cyrillic small letter u with double acute
+	'Ӵ': "Ч\u0308",             // cyrillic capital letter che with diaeresis
+	'ӵ': "ч\u0308",             // cyrillic small letter che with diaeresis
+	'Ӹ': "Ы\u0308",             // cyrillic capital letter yeru with diaeresis
+	'ӹ': "ы\u0308",             // cyrillic small letter yeru with diaeresis
+	'Ḁ': "A\u0325",             // latin capital letter a with ring below
+	'ḁ': "a\u0325",             // latin small letter a with ring below
+	'Ḃ': "B\u0307",             // latin capital letter b with dot above
+	'ḃ': "b\u0307",             // latin small letter b with dot above
+	'Ḅ': "B\u0323",             // latin capital letter b with dot below
+	'ḅ': "b\u0323",             // latin small letter b with dot below
+	'Ḇ': "B\u0331",             // latin capital letter b with line below
+	'ḇ': "b\u0331",             // latin small letter b with line below
+	'Ḉ': "C\u0327\u0301",       // latin capital letter c with cedilla and acute
+	'ḉ': "c\u0327\u0301",       // latin small letter c with cedilla and acute
+	'Ḋ': "D\u0307",             // latin capital letter d with dot above
+	'ḋ': "d\u0307",             // latin small letter d with dot above
+	'Ḍ': "D\u0323",             // latin capital letter d with dot below
+	'ḍ': "d\u0323",             // latin small letter d with dot below
+	'Ḏ': "D\u0331",             // latin capital letter d with line below
+	'ḏ': "d\u0331",             // latin small letter d with line below
+	'Ḑ': "D\u0327",             // latin capital letter d with cedilla
+	'ḑ': "d\u0327",             // latin small letter d with cedilla
+	'Ḓ': "D\u032D",             // latin capital letter d with circumflex below
+	'ḓ': "d\u032D",             // latin small letter d with circumflex below
+	'Ḕ': "E\u0304\u0300",       // latin capital letter e with macron and grave
+	'ḕ': "e\u0304\u0300",       // latin small letter e with macron and grave
+	'Ḗ': "E\u0304\u0301",       // latin capital letter e with macron and acute
+	'ḗ': "e\u0304\u0301",       // latin small letter e with macron and acute
+	'Ḙ': "E\u032D",             // latin capital letter e with circumflex below
+	'ḙ': "e\u032D",             // latin small letter e with circumflex below
+	'Ḛ': "E\u0330",             // latin capital letter e with tilde below
+	'ḛ': "e\u0330",             // latin small letter e with tilde below
+	'Ḝ': "E\u0327\u0306",       // latin capital letter e with cedilla and breve
+	'ḝ': "e\u0327\u0306",       // latin small letter e with cedilla and breve
+	'Ḟ': "F\u0307",             // latin capital letter f with dot above
+	'ḟ': "f\u0307",             // latin small letter f with dot above
+	'Ḡ': "G\u0304",             // latin capital letter g with macron
+	'ḡ': "g\u0304",             // latin small letter g with macron
+	'Ḣ': "H\u0307",             // latin capital letter h with dot above
+	'ḣ': "h\u0307",             // latin small letter h with dot above
+	'Ḥ': "H\u0323",             // latin capital letter h with dot below
+	'ḥ': "h\u0323",             // latin small letter h with dot below
+	'Ḧ': "H\u0308",             // latin capital letter h with diaeresis
+	'ḧ': "h\u0308",             // latin small letter h with diaeresis
+	'Ḩ': "H\u0327",             // latin capital letter h with cedilla
+	'ḩ': "h\u0327",             // latin small letter h with cedilla
+	'Ḫ': "H\u032E",             // latin capital letter h with breve below
+	'ḫ': "h\u032E",             // latin small letter h with breve below
+	'Ḭ': "I\u0330",             // latin capital letter i with tilde below
+	'ḭ': "i\u0330",             // latin small letter i with tilde below
+	'Ḯ': "I\u0308\u0301",       // latin capital letter i with diaeresis and acute
+	'ḯ': "i\u0308\u0301",       // latin small letter i with diaeresis and acute
+	'Ḱ': "K\u0301",             // latin capital letter k with acute
+	'ḱ': "k\u0301",             // latin small letter k with acute
+	'Ḳ': "K\u0323",             // latin capital letter k with dot below
+	'ḳ': "k\u0323",             // latin small letter k with dot below
+	'Ḵ': "K\u0331",             // latin capital letter k with line below
+	'ḵ': "k\u0331",             // latin small letter k with line below
+	'Ḷ': "L\u0323",             // latin capital letter l with dot below
+	'ḷ': "l\u0323",             // latin small letter l with dot below
+	'Ḹ': "L\u0323\u0304",       // latin capital letter l with dot below and macron
+	'ḹ': "l\u0323\u0304",       // latin small letter l with dot below and macron
+	'Ḻ': "L\u0331",             // latin capital letter l with line below
+	'ḻ': "l\u0331",             // latin small letter l with line below
+	'Ḽ': "L\u032D",             // latin capital letter l with circumflex below
+	'ḽ': "l\u032D",             // latin small letter l with circumflex below
+	'Ḿ': "M\u0301",             // latin capital letter m with acute
+	'ḿ': "m\u0301",             // latin small letter m with acute
+	'Ṁ': "M\u0307",             // latin capital letter m with dot above
+	'ṁ': "m\u0307",             // latin small letter m with dot above
+	'Ṃ': "M\u0323",             // latin capital letter m with dot below
+	'ṃ': "m\u0323",             // latin small letter m with dot below
+	'Ṅ': "N\u0307",             // latin capital letter n with dot above
+	'ṅ': "n\u0307",             // latin small letter n with dot above
+	'Ṇ': "N\u0323",             // latin capital letter n with dot below
+	'ṇ': "n\u0323",             // latin small letter n with dot below
+	'Ṉ': "N\u0331",             // latin capital letter n with line below
+	'ṉ': "n\u0331",             // latin small letter n with line below
+	'Ṋ': "N\u032D",             // latin capital letter n with circumflex below
+	'ṋ': "n\u032D",             // latin small letter n with circumflex below
+	'Ṍ': "O\u0303\u0301",       // latin capital letter o with tilde and acute
+	'ṍ': "o\u0303\u0301",       // latin small letter o with tilde and acute
+	'Ṏ': "O\u0303\u0308",       // latin capital letter o with tilde and diaeresis
+	'ṏ': "o\u0303\u0308",       // latin small letter o with tilde and diaeresis
+	'Ṑ': "O\u0304\u0300",       // latin capital letter o with macron and grave
+	'ṑ': "o\u0304\u0300",       // latin small letter o with macron and grave
+	'Ṓ': "O\u0304\u0301",       // latin capital letter o with macron and acute
+	'ṓ': "o\u0304\u0301",       // latin small letter o with macron and acute
+	'Ṕ': "P\u0301",             // latin capital letter p with acute
+	'ṕ': "p\u0301",             // latin small letter p with acute
+	'Ṗ': "P\u0307",             // latin capital letter p with dot above
+	'ṗ': "p\u0307",             // latin small letter p with dot above
+	'Ṙ': "R\u0307",             // latin capital letter r with dot above
+	'ṙ': "r\u0307",             // latin small letter r with dot above
+	'Ṛ': "R\u0323",             // latin capital letter r with dot below
+	'ṛ': "r\u0323",             // latin small letter r with dot below
+	'Ṝ': "R\u0323\u0304",       // latin capital letter r with dot below and macron
+	'ṝ': "r\u0323\u0304",       // latin small letter r with dot below and macron
+	'Ṟ': "R\u0331",             // latin capital letter r with line below
+	'ṟ': "r\u0331",             // latin small letter r with line below
+	'Ṡ': "S\u0307",             // latin capital letter s with dot above
+	'ṡ': "s\u0307",             // latin small letter s with dot above
+	'Ṣ': "S\u0323",             // latin capital letter s with dot below
+	'ṣ': "s\u0323",             // latin small letter s with dot below
+	'Ṥ': "S\u0301\u0307",       // latin capital letter s with acute and dot above
+	'ṥ': "s\u0301\u0307",       // latin small letter s with acute and dot above
+	'Ṧ': "S\u030C\u0307",       // latin capital letter s with caron and dot above
+	'ṧ': "s\u030C\u0307",       // latin small letter s with caron and dot above
+	'Ṩ': "S\u0323\u0307",       // latin capital letter s with dot below and dot above
+	'ṩ': "s\u0323\u0307",       // latin small letter s with dot below and dot above
+	'Ṫ': "T\u0307",             // latin capital letter t with dot above
+	'ṫ': "t\u0307",             // latin small letter t with dot above
+	'Ṭ': "T\u0323",             // latin capital letter t with dot below
+	'ṭ': "t\u0323",             // latin small letter t with dot below
+	'Ṯ': "T\u0331",             // latin capital letter t with line below
+	'ṯ': "t\u0331",             // latin small letter t with line below
+	'Ṱ': "T\u032D",             // latin capital letter t with circumflex below
+	'ṱ': "t\u032D",             // latin small letter t with circumflex below
+	'Ṳ': "U\u0324",             // latin capital letter u with diaeresis below
+	'ṳ': "u\u0324",             // latin small letter u with diaeresis below
+	'Ṵ': "U\u0330",             // latin capital letter u with tilde below
+	'ṵ': "u\u0330",             // latin small letter u with tilde below
+	'Ṷ': "U\u032D",             // latin capital letter u with circumflex below
+	'ṷ': "u\u032D",             // latin small letter u with circumflex below
+	'Ṹ': "U\u0303\u0301",       // latin capital letter u with tilde and acute
+	'ṹ': "u\u0303\u0301",       // latin small letter u with tilde and acute
+	'Ṻ': "U\u0304\u0308",       // latin capital letter u with macron and diaeresis
+	'ṻ': "u\u0304\u0308",       // latin small letter u with macron and diaeresis
+	'Ṽ': "V\u0303",             // latin capital letter v with tilde
+	'ṽ': "v\u0303",             // latin small letter v with tilde
+	'Ṿ': "V\u0323",             // latin capital letter v with dot below
+	'ṿ': "v\u0323",             // latin small letter v with dot below
+	'Ẁ': "W\u0300",             // latin capital letter w with grave
+	'ẁ': "w\u0300",             // latin small letter w with grave
+	'Ẃ': "W\u0301",             // latin capital letter w with acute
+	'ẃ': "w\u0301",             // latin small letter w with acute
+	'Ẅ': "W\u0308",             // latin capital letter w with diaeresis
+	'ẅ': "w\u0308",             // latin small letter w with diaeresis
+	'Ẇ': "W\u0307",             // latin capital letter w with dot above
+	'ẇ': "w\u0307",             // latin small letter w with dot above
+	'Ẉ': "W\u0323",             // latin capital letter w with dot below
+	'ẉ': "w\u0323",             // latin small letter w with dot below
+	'Ẋ': "X\u0307",             // latin capital letter x with dot above
+	'ẋ': "x\u0307",             // latin small letter x with dot above
+	'Ẍ': "X\u0308",             // latin capital letter x with diaeresis
+	'ẍ': "x\u0308",             // latin small letter x with diaeresis
+	'Ẏ': "Y\u0307",             // latin capital letter y with dot above
+	'ẏ': "y\u0307",             // latin small letter y with dot above
+	'Ẑ': "Z\u0302",             // latin capital letter z with circumflex
+	'ẑ': "z\u0302",             // latin small letter z with circumflex
+	'Ẓ': "Z\u0323",             // latin capital letter z with dot below
+	'ẓ': "z\u0323",             // latin small letter z with dot below
+	'Ẕ': "Z\u0331",             // latin capital letter z with line below
+	'ẕ': "z\u0331",             // latin small letter z with line below
+	'ẖ': "h\u0331",             // latin small letter h with line below
+	'ẗ': "t\u0308",             // latin small letter t with diaeresis
+	'ẘ': "w\u030A",             // latin small letter w with ring above
+	'ẙ': "y\u030A",             // latin small letter y with ring above
+	'ẛ': "ſ\u0307",             // latin small letter long s with dot above
+	'Ạ': "A\u0323",             // latin capital letter a with dot below
+	'ạ': "a\u0323",             // latin small letter a with dot below
+	'Ả': "A\u0309",             // latin capital letter a with hook above
+	'ả': "a\u0309",             // latin small letter a with hook above
+	'Ấ': "A\u0302\u0301",       // latin capital letter a with circumflex and acute
+	'ấ': "a\u0302\u0301",       // latin small letter a with circumflex and acute
+	'Ầ': "A\u0302\u0300",       // latin capital letter a with circumflex and grave
+	'ầ': "a\u0302\u0300",       // latin small letter a with circumflex and grave
+	'Ẩ': "A\u0302\u0309",       // latin capital letter a with circumflex and hook above
+	'ẩ': "a\u0302\u0309",       // latin small letter a with circumflex and hook above
+	'Ẫ': "A\u0302\u0303",       // latin capital letter a with circumflex and tilde
+	'ẫ': "a\u0302\u0303",       // latin small letter a with circumflex and tilde
+	'Ậ': "A\u0323\u0302",       // latin capital letter a with circumflex and dot below
+	'ậ': "a\u0323\u0302",       // latin small letter a with circumflex and dot below
+	'Ắ': "A\u0306\u0301",       // latin capital letter a with breve and acute
+	'ắ': "a\u0306\u0301",       // latin small letter a with breve and acute
+	'Ằ': "A\u0306\u0300",       // latin capital letter a with breve and grave
+	'ằ': "a\u0306\u0300",       // latin small letter a with breve and grave
+	'Ẳ': "A\u0306\u0309",       // latin capital letter a with breve and hook above
+	'ẳ': "a\u0306\u0309",       // latin small letter a with breve and hook above
+	'Ẵ': "A\u0306\u0303",       // latin capital letter a with breve and tilde
+	'ẵ': "a\u0306\u0303",       // latin small letter a with breve and tilde
+	'Ặ': "A\u0323\u0306",       // latin capital letter a with breve and dot below
+	'ặ': "a\u0323\u0306",       // latin small letter a with breve and dot below
+	'Ẹ': "E\u0323",             // latin capital letter e with dot below
+	'ẹ': "e\u0323",             // latin small letter e with dot below
+	'Ẻ': "E\u0309",             // latin capital letter e with hook above
+	'ẻ': "e\u0309",             // latin small letter e with hook above
+	'Ẽ': "E\u0303",             // latin capital letter e with tilde
+	'ẽ': "e\u0303",             // latin small letter e with tilde
+	'Ế': "E\u0302\u0301",       // latin capital letter e with circumflex and acute
+	'ế': "e\u0302\u0301",       // latin small letter e with circumflex and acute
+	'Ề': "E\u0302\u0300",       // latin capital letter e with circumflex and grave
+	'ề': "e\u0302\u0300",       // latin small letter e with circumflex and grave
+	'Ể': "E\u0302\u0309",       // latin capital letter e with circumflex and hook above
+	'ể': "e\u0302\u0309",       // latin small letter e with circumflex and hook above
+	'Ễ': "E\u0302\u0303",       // latin capital letter e with circumflex and tilde
+	'ễ': "e\u0302\u0303",       // latin small letter e with circumflex and tilde
+	'Ệ': "E\u0323\u0302",       // latin capital letter e with circumflex and dot below
+	'ệ': "e\u0323\u0302",       // latin small letter e with circumflex and dot below
+	'Ỉ': "I\u0309",             // latin capital letter i with hook above
+	'ỉ': "i\u0309",             // latin small letter i with hook above
+	'Ị': "I\u0323",             // latin capital letter i with dot below
+	'ị': "i\u0323",             // latin small letter i with dot below
+	'Ọ': "O\u0323",             // latin capital letter o with dot below
+	'ọ': "o\u0323",             // latin small letter o with dot below
+	'Ỏ': "O\u0309",             // latin capital letter o with hook above
+	'ỏ': "o\u0309",             // latin small letter o with hook above
+	'Ố': "O\u0302\u0301",       // latin capital letter o with circumflex and acute
+	'ố': "o\u0302\u0301",       // latin small letter o with circumflex and acute
+	'Ồ': "O\u0302\u0300",       // latin capital letter o with circumflex and grave
+	'ồ': "o\u0302\u0300",       // latin small letter o with circumflex and grave
+	'Ổ': "O\u0302\u0309",       // latin capital letter o with circumflex and hook above
+	'ổ': "o\u0302\u0309",       // latin small letter o with circumflex and hook above
+	'Ỗ': "O\u0302\u0303",       // latin capital letter o with circumflex and tilde
+	'ỗ': "o\u0302\u0303",       // latin small letter o with circumflex and tilde
+	'Ộ': "O\u0323\u0302",       // latin capital letter o with circumflex and dot below
+	'ộ': "o\u0323\u0302",       // latin small letter o with circumflex and dot below
+	'Ớ': "O\u031B\u0301",       // latin capital letter o with horn and acute
+	'ớ': "o\u031B\u0301",       // latin small letter o with horn and acute
+	'Ờ': "O\u031B\u0300",       // latin capital letter o with horn and grave
+	'ờ': "o\u031B\u0300",       // latin small letter o with horn and grave
+	'Ở': "O\u031B\u0309",       // latin capital letter o with horn and hook above
+	'ở': "o\u031B\u0309",       // latin small letter o with horn and hook above
+	'Ỡ': "O\u031B\u0303",       // latin capital letter o with horn and tilde
+	'ỡ': "o\u031B\u0303",       // latin small letter o with horn and tilde
+	'Ợ': "O\u031B\u0323",       // latin capital letter o with horn and dot below
+	'ợ': "o\u031B\u0323",       // latin small letter o with horn and dot below
+	'Ụ': "U\u0323",             // latin capital letter u with dot below
+	'ụ': "u\u0323",             // latin small letter u with dot below
+	'Ủ': "U\u0309",             // latin capital letter u with hook above
+	'ủ': "u\u0309",             // latin small letter u with hook above
+	'Ứ': "U\u031B\u0301",       // latin capital letter u with horn and acute
+	'ứ': "u\u031B\u0301",       // latin small letter u with horn and acute
+	'Ừ': "U\u031B\u0300",       // latin capital letter u with horn and grave
+	'ừ': "u\u031B\u0300",       // latin small letter u with horn and grave
+	'Ử': "U\u031B\u0309",       // latin capital letter u with horn and hook above
+	'ử': "u\u031B\u0309",       // latin small letter u with horn and hook above
+	'Ữ': "U\u031B\u0303",       // latin capital letter u with horn and tilde
+	'ữ': "u\u031B\u0303",       // latin small letter u with horn and tilde
+	'Ự': "U\u031B\u0323",       // latin capital letter u with horn and dot below
+	'ự': "u\u031B\u0323",       // latin small letter u with horn and dot below
+	'Ỳ': "Y\u0300",             // latin capital letter y with grave
+	'ỳ': "y\u0300",             // latin small letter y with grave
+	'Ỵ': "Y\u0323",             // latin capital letter y with dot below
+	'ỵ': "y\u0323",             // latin small letter y with dot below
+	'Ỷ': "Y\u0309",             // latin capital letter y with hook above
+	'ỷ': "y\u0309",             // latin small letter y with hook above
+	'Ỹ': "Y\u0303",             // latin capital letter y with tilde
+	'ỹ': "y\u0303",             // latin small letter y with tilde
+	'ἀ': "α\u0313",             // greek small letter alpha with psili
+	'ἁ': "α\u0314",             // greek small letter alpha with dasia
+	'ἂ': "α\u0313\u0300",       // greek small letter alpha with psili and varia
+	'ἃ': "α\u0314\u0300",       // greek small letter alpha with dasia and varia
+	'ἄ': "α\u0313\u0301",       // greek small letter alpha with psili and oxia
+	'ἅ': "α\u0314\u0301",       // greek small letter alpha with dasia and oxia
+	'ἆ': "α\u0313\u0342",       // greek small letter alpha with psili and perispomeni
+	'ἇ': "α\u0314\u0342",       // greek small letter alpha with dasia and perispomeni
+	'Ἀ': "Α\u0313",             // greek capital letter alpha with psili
+	'Ἁ': "Α\u0314",             // greek capital letter alpha with dasia
+	'Ἂ': "Α\u0313\u0300",       // greek capital letter alpha with psili and varia
+	'Ἃ': "Α\u0314\u0300",       // greek capital letter alpha with dasia and varia
+	'Ἄ': "Α\u0313\u0301",       // greek capital letter alpha with psili and oxia
+	'Ἅ': "Α\u0314\u0301",       // greek capital letter alpha with dasia and oxia
+	'Ἆ': "Α\u0313\u0342",       // greek capital letter alpha with psili and perispomeni
+	'Ἇ': "Α\u0314\u0342",       // greek capital letter alpha with dasia and perispomeni
+	'ἐ': "ε\u0313",             // greek small letter epsilon with psili
+	'ἑ': "ε\u0314",             // greek small letter epsilon with dasia
+	'ἒ': "ε\u0313\u0300",       // greek small letter epsilon with psili and varia
+	'ἓ': "ε\u0314\u0300",       // greek small letter epsilon with dasia and varia
+	'ἔ': "ε\u0313\u0301",       // greek small letter epsilon with psili and oxia
+	'ἕ': "ε\u0314\u0301",       // greek small letter epsilon with dasia and oxia
+	'Ἐ': "Ε\u0313",             // greek capital letter epsilon with psili
+	'Ἑ': "Ε\u0314",             // greek capital letter epsilon with dasia
+	'Ἒ': "Ε\u0313\u0300",       // greek capital letter epsilon with psili and varia
+	'Ἓ': "Ε\u0314\u0300",       // greek capital letter epsilon with dasia and varia
+	'Ἔ': "Ε\u0313\u0301",       // greek capital letter epsilon with psili and oxia
+	'Ἕ': "Ε\u0314\u0301",       // greek capital letter epsilon with dasia and oxia
+	'ἠ': "η\u0313",             // greek small letter eta with psili
+	'ἡ': "η\u0314",             // greek small letter eta with dasia
+	'ἢ': "η\u0313\u0300",       // greek small letter eta with psili and varia
+	'ἣ': "η\u0314\u0300",       // greek small letter eta with dasia and varia
+	'ἤ': "η\u0313\u0301",       // greek small letter eta with psili and oxia
+	'ἥ': "η\u0314\u0301",       // greek small letter eta with dasia and oxia
+	'ἦ': "η\u0313\u0342",       // greek small letter eta with psili and perispomeni
+	'ἧ': "η\u0314\u0342",       // greek small letter eta with dasia and perispomeni
+	'Ἠ': "Η\u0313",             // greek capital letter eta with psili
+	'Ἡ': "Η\u0314",             // greek capital letter eta with dasia
+	'Ἢ': "Η\u0313\u0300",       // greek capital letter eta with psili and varia
+	'Ἣ': "Η\u0314\u0300",       // greek capital letter eta with dasia and varia
+	'Ἤ': "Η\u0313\u0301",       // greek capital letter eta with psili and oxia
+	'Ἥ': "Η\u0314\u0301",       // greek capital letter eta with dasia and oxia
+	'Ἦ': "Η\u0313\u0342",       // greek capital letter eta with psili and perispomeni
+	'Ἧ': "Η\u0314\u0342",       // greek capital letter eta with dasia and perispomeni
+	'ἰ': "ι\u0313",             // greek small letter iota with psili
+	'ἱ': "ι\u0314",             // greek small letter iota with dasia
+	'ἲ': "ι\u0313\u0300",       // greek small letter iota with psili and varia
+	'ἳ': "ι\u0314\u0300",       // greek small letter iota with dasia and varia
+	'ἴ': "ι\u0313\u0301",       // greek small letter iota with psili and oxia
+	'ἵ': "ι\u0314\u0301",       // greek small letter iota with dasia and oxia
+	'ἶ': "ι\u0313\u0342",       // greek small letter iota with psili and perispomeni
+	'ἷ': "ι\u0314\u0342",       // greek small letter iota with dasia and perispomeni
+	'Ἰ': "Ι\u0313",             // greek capital letter iota with psili
+	'Ἱ': "Ι\u0314",             // greek capital letter iota with dasia
+	'Ἲ': "Ι\u0313\u0300",       // greek capital letter iota with psili and varia
+	'Ἳ': "Ι\u0314\u0300",       // greek capital letter iota with dasia and varia
+	'Ἴ': "Ι\u0313\u0301",       // greek capital letter iota with psili and oxia
+	'Ἵ': "Ι\u0314\u0301",       // greek capital letter iota with dasia and oxia
+	'Ἶ': "Ι\u0313\u0342",       // greek capital letter iota with psili and perispomeni
+	'Ἷ': "Ι\u0314\u0342",       // greek capital letter iota with dasia and perispomeni
+	'ὀ': "ο\u0313",             // greek small letter omicron with psili
+	'ὁ': "ο\u0314",             // greek small letter omicron with dasia
+	'ὂ': "ο\u0313\u0300",       // greek small letter omicron with psili and varia
+	'ὃ': "ο\u0314\u0300",       // greek small letter omicron with dasia and varia
+	'ὄ': "ο\u0313\u0301",       // greek small letter omicron with psili and oxia
+	'ὅ': "ο\u0314\u0301",       // greek small letter omicron with dasia and oxia
+	'Ὀ': "Ο\u0313",             // greek capital letter omicron with psili
+	'Ὁ': "Ο\u0314",             // greek capital letter omicron with dasia
+	'Ὂ': "Ο\u0313\u0300",       // greek capital letter omicron with psili and varia
+	'Ὃ': "Ο\u0314\u0300",       // greek capital letter omicron with dasia and varia
+	'Ὄ': "Ο\u0313\u0301",       // greek capital letter omicron with psili and oxia
+	'Ὅ': "Ο\u0314\u0301",       // greek capital letter omicron with dasia and oxia
+	'ὐ': "υ\u0313",             // greek small letter upsilon with psili
+	'ὑ': "υ\u0314",             // greek small letter upsilon with dasia
+	'ὒ': "υ\u0313\u0300",       // greek small letter upsilon with psili and varia
+	'ὓ': "υ\u0314\u0300",       // greek small letter upsilon with dasia and varia
+	'ὔ': "υ\u0313\u0301",       // greek small letter upsilon with psili and oxia
+	'ὕ': "υ\u0314\u0301",       // greek small letter upsilon with dasia and oxia
+	'ὖ': "υ\u0313\u0342",       // greek small letter upsilon with psili and perispomeni
+	'ὗ': "υ\u0314\u0342",       // greek small letter upsilon with dasia and perispomeni
+	'Ὑ': "Υ\u0314",             // greek capital letter upsilon with dasia
+	'Ὓ': "Υ\u0314\u0300",       // greek capital letter upsilon with dasia and varia
+	'Ὕ': "Υ\u0314\u0301",       // greek capital letter upsilon with dasia and oxia
+	'Ὗ': "Υ\u0314\u0342",       // greek capital letter upsilon with dasia and perispomeni
+	'ὠ': "ω\u0313",             // greek small letter omega with psili
+	'ὡ': "ω\u0314",             // greek small letter omega with dasia
+	'ὢ': "ω\u0313\u0300",       // greek small letter omega with psili and varia
+	'ὣ': "ω\u0314\u0300",       // greek small letter omega with dasia and varia
+	'ὤ': "ω\u0313\u0301",       // greek small letter omega with psili and oxia
+	'ὥ': "ω\u0314\u0301",       // greek small letter omega with dasia and oxia
+	'ὦ': "ω\u0313\u0342",       // greek small letter omega with psili and perispomeni
+	'ὧ': "ω\u0314\u0342",       // greek small letter omega with dasia and perispomeni
+	'Ὠ': "Ω\u0313",             // greek capital letter omega with psili
+	'Ὡ': "Ω\u0314",             // greek capital letter omega with dasia
+	'Ὢ': "Ω\u0313\u0300",       // greek capital letter omega with psili and varia
+	'Ὣ': "Ω\u0314\u0300",       // greek capital letter omega with dasia and varia
+	'Ὤ': "Ω\u0313\u0301",       // greek capital letter omega with psili and oxia
+	'Ὥ': "Ω\u0314\u0301",       // greek capital letter omega with dasia and oxia
+	'Ὦ': "Ω\u0313\u0342",       // greek capital letter omega with psili and perispomeni
+	'Ὧ': "Ω\u0314\u0342",       // greek capital letter omega with dasia and perispomeni
+	'ὰ': "α\u0300",             // greek small letter alpha with varia
+	'ά': "α\u0301",             // greek small letter alpha with oxia
+	'ὲ': "ε\u0300",             // greek small letter epsilon with varia
+	'έ': "ε\u0301",             // greek small letter epsilon with oxia
+	'ὴ': "η\u0300",             // greek small letter eta with varia
+	'ή': "η\u0301",             // greek small letter eta with oxia
+	'ὶ': "ι\u0300",             // greek small letter iota with varia
+	'ί': "ι\u0301",             // greek small letter iota with oxia
+	'ὸ': "ο\u0300",             // greek small letter omicron with varia
+	'ό': "ο\u0301",             // greek small letter omicron with oxia
+	'ὺ': "υ\u0300",             // greek small letter upsilon with varia
+	'ύ': "υ\u0301",             // greek small letter upsilon with oxia
+	'ὼ': "ω\u0300",             // greek small letter omega with varia
+	'ώ': "ω\u0301",             // greek small letter omega with oxia
+	'ᾀ': "α\u0313\u0345",       // greek small letter alpha with psili and ypogegrammeni
+	'ᾁ': "α\u0314\u0345",       // greek small letter alpha with dasia and ypogegrammeni
+	'ᾂ': "α\u0313\u0300\u0345", // greek small letter alpha with psili and varia and ypogegrammeni
+	'ᾃ': "α\u0314\u0300\u0345", // greek small letter alpha with dasia and varia and ypogegrammeni
+	'ᾄ': "α\u0313\u0301\u0345", // greek small letter alpha with psili and oxia and ypogegrammeni
+	'ᾅ': "α\u0314\u0301\u0345", // greek small letter alpha with dasia and oxia and ypogegrammeni
+	'ᾆ': "α\u0313\u0342\u0345", // greek small letter alpha with psili and perispomeni and ypogegrammeni
+	'ᾇ': "α\u0314\u0342\u0345", // greek small letter alpha with dasia and perispomeni and ypogegrammeni
+	'ᾈ': "Α\u0313\u0345",       // greek capital letter alpha with psili and prosgegrammeni
+	'ᾉ': "Α\u0314\u0345",       // greek capital letter alpha with dasia and prosgegrammeni
+	'ᾊ': "Α\u0313\u0300\u0345", // greek capital letter alpha with psili and varia and prosgegrammeni
+	'ᾋ': "Α\u0314\u0300\u0345", // greek capital letter alpha with dasia and varia and prosgegrammeni
+	'ᾌ': "Α\u0313\u0301\u0345", // greek capital letter alpha with psili and oxia and prosgegrammeni
+	'ᾍ': "Α\u0314\u0301\u0345", // greek capital letter alpha with dasia and oxia and prosgegrammeni
+	'ᾎ': "Α\u0313\u0342\u0345", // greek capital letter alpha with psili and perispomeni and prosgegrammeni
+	'ᾏ': "Α\u0314\u0342\u0345", // greek capital letter alpha with dasia and perispomeni and prosgegrammeni
+	'ᾐ': "η\u0313\u0345",       // greek small letter eta with psili and ypogegrammeni
+	'ᾑ': "η\u0314\u0345",       // greek small letter eta with dasia and ypogegrammeni
+	'ᾒ': "η\u0313\u0300\u0345", // greek small letter eta with psili and varia and ypogegrammeni
+	'ᾓ': "η\u0314\u0300\u0345", // greek small letter eta with dasia and varia and ypogegrammeni
+	'ᾔ': "η\u0313\u0301\u0345", // greek small letter eta with psili and oxia and ypogegrammeni
+	'ᾕ': "η\u0314\u0301\u0345", // greek small letter eta with dasia and oxia and ypogegrammeni
+	'ᾖ': "η\u0313\u0342\u0345", // greek small letter eta with psili and perispomeni and ypogegrammeni
+	'ᾗ': "η\u0314\u0342\u0345", // greek small letter eta with dasia and perispomeni and ypogegrammeni
+	'ᾘ': "Η\u0313\u0345",       // greek capital letter eta with psili and prosgegrammeni
+	'ᾙ': "Η\u0314\u0345",       // greek capital letter eta with dasia and prosgegrammeni
+	'ᾚ': "Η\u0313\u0300\u0345", // greek capital letter eta with psili and varia and prosgegrammeni
+	'ᾛ': "Η\u0314\u0300\u0345", // greek capital letter eta with dasia and varia and prosgegrammeni
+	'ᾜ': "Η\u0313\u0301\u0345", // greek capital letter eta with psili and oxia and prosgegrammeni
+	'ᾝ': "Η\u0314\u0301\u0345", // greek capital letter eta with dasia and oxia and prosgegrammeni
+	'ᾞ': "Η\u0313\u0342\u0345", // greek capital letter eta with psili and perispomeni and prosgegrammeni
+	'ᾟ': "Η\u0314\u0342\u0345", // greek capital letter eta with dasia and perispomeni and prosgegrammeni
+	'ᾠ': "ω\u0313\u0345",       // greek small letter omega with psili and ypogegrammeni
+	'ᾡ': "ω\u0314\u0345",       // greek small letter omega with dasia and ypogegrammeni
+	'ᾢ': "ω\u0313\u0300\u0345", // greek small letter omega with psili and varia and ypogegrammeni
+	'ᾣ': "ω\u0314\u0300\u0345", // greek small letter omega with dasia and varia and ypogegrammeni
+	'ᾤ': "ω\u0313\u0301\u0345", // greek small letter omega with psili and oxia and ypogegrammeni
+	'ᾥ': "ω\u0314\u0301\u0345", // greek small letter omega with dasia and oxia and ypogegrammeni
+	'ᾦ': "ω\u0313\u0342\u0345", // greek small letter omega with psili and perispomeni and ypogegrammeni
+	'ᾧ': "ω\u0314\u0342\u0345", // greek small letter omega with dasia and perispomeni and ypogegrammeni
+	'ᾨ': "Ω\u0313\u0345",       // greek capital letter omega with psili and prosgegrammeni
+	'ᾩ': "Ω\u0314\u0345",       // greek capital letter omega with dasia and prosgegrammeni
+	'ᾪ': "Ω\u0313\u0300\u0345", // greek capital letter omega with psili and varia and prosgegrammeni
+	'ᾫ': "Ω\u0314\u0300\u0345", // greek capital letter omega with dasia and varia and prosgegrammeni
+	'ᾬ': "Ω\u0313\u0301\u0345", // greek capital letter omega with psili and oxia and prosgegrammeni
+	'ᾭ': "Ω\u0314\u0301\u0345", // greek capital letter omega with dasia and oxia and prosgegrammeni
+	'ᾮ': "Ω\u0313\u0342\u0345", // greek capital letter omega with psili and perispomeni and prosgegrammeni
+	'ᾯ': "Ω\u0314\u0342\u0345", // greek capital letter omega with dasia and perispomeni and prosgegrammeni
+	'ᾰ': "α\u0306",             // greek small letter alpha with vrachy
+	'ᾱ': "α\u0304",             // greek small letter alpha with macron
+	'ᾲ': "α\u0300\u0345",       // greek small letter alpha with varia and ypogegrammeni
+	'ᾳ': "α\u0345",             // greek small letter alpha with ypogegrammeni
+	'ᾴ': "α\u0301\u0345",       // greek small letter alpha with oxia and ypogegrammeni
+	'ᾶ': "α\u0342",             // greek small letter alpha with perispomeni
+	'ᾷ': "α\u0342\u0345",       // greek small letter alpha with perispomeni and ypogegrammeni
+	'Ᾰ': "Α\u0306",             // greek capital letter alpha with vrachy
+	'Ᾱ': "Α\u0304",             // greek capital letter alpha with macron
+	'Ὰ': "Α\u0300",             // greek capital letter alpha with varia
+	'Ά': "Α\u0301",             // greek capital letter alpha with oxia
+	'ᾼ': "Α\u0345",             // greek capital letter alpha with prosgegrammeni
+	'ι': "ι",                   // greek prosgegrammeni
+	'῁': "¨\u0342",             // greek dialytika and perispomeni
+	'ῂ': "η\u0300\u0345",       // greek small letter eta with varia and ypogegrammeni
+	'ῃ': "η\u0345",             // greek small letter eta with ypogegrammeni
+	'ῄ': "η\u0301\u0345",       // greek small letter eta with oxia and ypogegrammeni
+	'ῆ': "η\u0342",             // greek small letter eta with perispomeni
+	'ῇ': "η\u0342\u0345",       // greek small letter eta with perispomeni and ypogegrammeni
+	'Ὲ': "Ε\u0300",             // greek capital letter epsilon with varia
+	'Έ': "Ε\u0301",             // greek capital letter epsilon with oxia
+	'Ὴ': "Η\u0300",             // greek capital letter eta with varia
+	'Ή': "Η\u0301",             // greek capital letter eta with oxia
+	'ῌ': "Η\u0345",             // greek capital letter eta with prosgegrammeni
+	'῍': "᾿\u0300",             // greek psili and varia
+	'῎': "᾿\u0301",             // greek psili and oxia
+	'῏': "᾿\u0342",             // greek psili and perispomeni
+	'ῐ': "ι\u0306",             // greek small letter iota with vrachy
+	'ῑ': "ι\u0304",             // greek small letter iota with macron
+	'ῒ': "ι\u0308\u0300",       // greek small letter iota with dialytika and varia
+	'ΐ': "ι\u0308\u0301",       // greek small letter iota with dialytika and oxia
+	'ῖ': "ι\u0342",             // greek small letter iota with perispomeni
+	'ῗ': "ι\u0308\u0342",       // greek small letter iota with dialytika and perispomeni
+	'Ῐ': "Ι\u0306",             // greek capital letter iota with vrachy
+	'Ῑ': "Ι\u0304",             // greek capital letter iota with macron
+	'Ὶ': "Ι\u0300",             // greek capital letter iota with varia
+	'Ί': "Ι\u0301",             // greek capital letter iota with oxia
+	'῝': "῾\u0300",             // greek dasia and varia
+	'῞': "῾\u0301",             // greek dasia and oxia
+	'῟': "῾\u0342",             // greek dasia and perispomeni
+	'ῠ': "υ\u0306",             // greek small letter upsilon with vrachy
+	'ῡ': "υ\u0304",             // greek small letter upsilon with macron
+	'ῢ': "υ\u0308\u0300",       // greek small letter upsilon with dialytika and varia
+	'ΰ': "υ\u0308\u0301",       // greek small letter upsilon with dialytika and oxia
+	'ῤ': "ρ\u0313",             // greek small letter rho with psili
+	'ῥ': "ρ\u0314",             // greek small letter rho with dasia
+	'ῦ': "υ\u0342",             // greek small letter upsilon with perispomeni
+	'ῧ': "υ\u0308\u0342",       // greek small letter upsilon with dialytika and perispomeni
+	'Ῠ': "Υ\u0306",             // greek capital letter upsilon with vrachy
+	'Ῡ': "Υ\u0304",             // greek capital letter upsilon with macron
+	'Ὺ': "Υ\u0300",             // greek capital letter upsilon with varia
+	'Ύ': "Υ\u0301",             // greek capital letter upsilon with oxia
+	'Ῥ': "Ρ\u0314",             // greek capital letter rho with dasia
+	'῭': "¨\u0300",             // greek dialytika and varia
+	'΅': "¨\u0301",             // greek dialytika and oxia
+	'`': "`",                   // greek varia
+	'ῲ': "ω\u0300\u0345",       // greek small letter omega with varia and ypogegrammeni
+	'ῳ': "ω\u0345",             // greek small letter omega with ypogegrammeni
+	'ῴ': "ω\u0301\u0345",       // greek small letter omega with oxia and ypogegrammeni
+	'ῶ': "ω\u0342",             // greek small letter omega with perispomeni
+	'ῷ': "ω\u0342\u0345",       // greek small letter omega with perispomeni and ypogegrammeni
+	'Ὸ': "Ο\u0300",             // greek capital letter omicron with varia
+	'Ό': "Ο\u0301",             // greek capital letter omicron with oxia
+	'Ὼ': "Ω\u0300",             // greek capital letter omega with varia
+	'Ώ': "Ω\u0301",             // greek capital letter omega with oxia
+	'ῼ': "Ω\u0345",             // greek capital letter omega with prosgegrammeni
+	'´': "´",                   // greek oxia
+	'Ω': "Ω",                   // ohm sign
+	'K': "K",                   // kelvin sign
+	'Å': "A\u030A",             // angstrom sign
+}
+
+// compatibility are the compatibility forms (NFKC) of the runes, e.g. "ﬁ" is "fi" and "²" is "2".
+var compatibility = map[rune]string{
+	0x00A0: " ",             // no-break space
+	'¨':    " \u0308",       // diaeresis
+	'ª':    "a",             // feminine ordinal indicator
+	'¯':    " \u0304",       // macron
+	'²':    "2",             // superscript two
+	'³':    "3",             // superscript three
+	'´':    " \u0301",       // acute accent
+	'µ':    "μ",             // micro sign
+	'¸':    " \u0327",       // cedilla
+	'¹':    "1",             // superscript one
+	'º':    "o",             // masculine ordinal indicator
+	'¼':    "1⁄4",           // vulgar fraction one quarter
+	'½':    "1⁄2",           // vulgar fraction one half
+	'¾':    "3⁄4",           // vulgar fraction three quarters
+	'Ĳ':    "IJ",            // latin capital ligature ij
+	'ĳ':    "ij",            // latin small ligature ij
+	'Ŀ':    "L·",            // latin capital letter l with middle dot
+	'ŀ':    "l·",            // latin small letter l with middle dot
+	'ŉ':    "ʼn",            // latin small letter n preceded by apostrophe
+	'ſ':    "s",             // latin small letter long s
+	'Ǆ':    "DŽ",            // latin capital letter dz with caron
+	'ǅ':    "Dž",            // latin capital letter d with small letter z with caron
+	'ǆ':    "dž",            // latin small letter dz with caron
+	'Ǉ':    "LJ",            // latin capital letter lj
+	'ǈ':    "Lj",            // latin capital letter l with small letter j
+	'ǉ':    "lj",            // latin small letter lj
+	'Ǌ':    "NJ",            // latin capital letter nj
+	'ǋ':    "Nj",            // latin capital letter n with small letter j
+	'ǌ':    "nj",            // latin small letter nj
+	'Ǳ':    "DZ",            // latin capital letter dz
+	'ǲ':    "Dz",            // latin capital letter d with small letter z
+	'ǳ':    "dz",            // latin small letter dz
+	'ʰ':    "h",             // modifier letter small h
+	'ʱ':    "ɦ",             // modifier letter small h with hook
+	'ʲ':    "j",             // modifier letter small j
+	'ʳ':    "r",             // modifier letter small r
+	'ʴ':    "ɹ",             // modifier letter small turned r
+	'ʵ':    "ɻ",             // modifier letter small turned r with hook
+	'ʶ':    "ʁ",             // modifier letter small capital inverted r
+	'ʷ':    "w",             // modifier letter small w
+	'ʸ':    "y",             // modifier letter small y
+	'˘':    " \u0306",       // breve
+	'˙':    " \u0307",       // dot above
+	'˚':    " \u030A",       // ring above
+	'˛':    " \u0328",       // ogonek
+	'˜':    " \u0303",       // small tilde
+	'˝':    " \u030B",       // double acute accent
+	'ˠ':    "ɣ",             // modifier letter small gamma
+	'ˡ':    "l",             // modifier letter small l
+	'ˢ':    "s",             // modifier letter small s
+	'ˣ':    "x",             // modifier letter small x
+	'ˤ':    "ʕ",             // modifier letter small reversed glottal stop
+	'ͺ':    " \u0345",       // greek ypogegrammeni
+	'΄':    " \u0301",       // greek tonos
+	'΅':    " \u0308\u0301", // greek dialytika tonos
+	'ϐ':    "β",             // greek beta symbol
+	'ϑ':    "θ",             // greek theta symbol
+	'ϒ':    "Υ",             // greek upsilon with hook symbol
+	'ϓ':    "Ύ",             // greek upsilon with acute and hook symbol
+	'ϔ':    "Ϋ",             // greek upsilon with diaeresis and hook symbol
+	'ϕ':    "φ",             // greek phi symbol
+	'ϖ':    "π",             // greek pi symbol
+	'ϰ':    "κ",             // greek kappa symbol
+	'ϱ':    "ρ",             // greek rho symbol
+	'ϲ':    "ς",             // greek lunate sigma symbol
+	'ϴ':    "Θ",             // greek capital theta symbol
+	'ϵ':    "ε",             // greek lunate epsilon symbol
+	'Ϲ':    "Σ",             // greek capital lunate sigma symbol
+	'ᴬ':    "A",             // modifier letter capital a
+	'ᴭ':    "Æ",             // modifier letter capital ae
+	'ᴮ':    "B",             // modifier letter capital b
+	'ᴰ':    "D",             // modifier letter capital d
+	'ᴱ':    "E",             // modifier letter capital e
+	'ᴲ':    "Ǝ",             // modifier letter capital reversed e
+	'ᴳ':    "G",             // modifier letter capital g
+	'ᴴ':    "H",             // modifier letter capital h
+	'ᴵ':    "I",             // modifier letter capital i
+	'ᴶ':    "J",             // modifier letter capital j
+	'ᴷ':    "K",             // modifier letter capital k
+	'ᴸ':    "L",             // modifier letter capital l
+	'ᴹ':    "M",             // modifier letter capital m
+	'ᴺ':    "N",             // modifier letter capital n
+	'ᴼ':    "O",             // modifier letter capital o
+	'ᴽ':    "Ȣ",             // modifier letter capital ou
+	'ᴾ':    "P",             // modifier letter capital p
+	'ᴿ':    "R",             // modifier letter capital r
+	'ᵀ':    "T",             // modifier letter capital t
+	'ᵁ':    "U",             // modifier letter capital u
+	'ᵂ':    "W",             // modifier letter capital w
+	'ᵃ':    "a",             // modifier letter small a
+	'ᵄ':    "ɐ",             // modifier letter small turned a
+	'ᵅ':    "ɑ",             // modifier letter small alpha
+	'ᵆ':    "ᴂ",             // modifier letter small turned ae
+	'ᵇ':    "b",             // modifier letter small b
+	'ᵈ':    "d",             // modifier letter small d
+	'ᵉ':    "e",             // modifier letter small e
+	'ᵊ':    "ə",             // modifier letter small schwa
+	'ᵋ':    "ɛ",             // modifier letter small open e
+	'ᵌ':    "ɜ",             // modifier letter small turned open e
+	'ᵍ':    "g",             // modifier letter small g
+	'ᵏ':    "k",             // modifier letter small k
+	'ᵐ':    "m",             // modifier letter small m
+	'ᵑ':    "ŋ",             // modifier letter small eng
+	'ᵒ':    "o",             // modifier letter small o
+	'ᵓ':    "ɔ",             // modifier letter small open o
+	'ᵔ':    "ᴖ",             // modifier letter small top half o
+	'ᵕ':    "ᴗ",             // modifier letter small bottom half o
+	'ᵖ':    "p",             // modifier letter small p
+	'ᵗ':    "t",             // modifier letter small t
+	'ᵘ':    "u",             // modifier letter small u
+	'ᵙ':    "ᴝ",             // modifier letter small sideways u
+	'ᵚ':    "ɯ",             // modifier letter small turned m
+	'ᵛ':    "v",             // modifier letter small v
+	'ᵜ':    "ᴥ",             // modifier letter small ain
+	'ᵝ':    "β",             // modifier letter small beta
+	'ᵞ':    "γ",             // modifier letter small greek gamma
+	'ᵟ':    "δ",             // modifier letter small delta
+	'ᵠ':    "φ",             // modifier letter small greek phi
+	'ᵡ':    "χ",             // modifier letter small chi
+	'ᵢ':    "i",             // latin subscript small letter i
+	'ᵣ':    "r",             // latin subscript small letter r
+	'ᵤ':    "u",             // latin subscript small letter u
+	'ᵥ':    "v",             // latin subscript small letter v
+	'ᵦ':    "β",             // greek subscript small letter beta
+	'ᵧ':    "γ",             // greek subscript small letter gamma
+	'ᵨ':    "ρ",             // greek subscript small letter rho
+	'ᵩ':    "φ",             // greek subscript small letter phi
+	'ᵪ':    "χ",             // greek subscript small letter chi
+	'ᵸ':    "н",             // modifier letter cyrillic en
+	'ᶛ':    "ɒ",             // modifier letter small turned alpha
+	'ᶜ':    "c",             // modifier letter small c
+	'ᶝ':    "ɕ",             // modifier letter small c with curl
+	'ᶞ':    "ð",             // modifier letter small eth
+	'ᶟ':    "ɜ",             // modifier letter small reversed open e
+	'ᶠ':    "f",             // modifier letter small f
+	'ᶡ':    "ɟ",             // modifier letter small dotless j with stroke
+	'ᶢ':    "ɡ",             // modifier letter small script g
+	'ᶣ':    "ɥ",             // modifier letter small turned h
+	'ᶤ':    "ɨ",             // modifier letter small i with stroke
+	'ᶥ':    "ɩ",             // modifier letter small iota
+	'ᶦ':    "ɪ",             // modifier letter small capital i
+	'ᶧ':    "ᵻ",             // modifier letter small capital i with stroke
+	'ᶨ':    "ʝ",             // modifier letter small j with crossed-tail
+	'ᶩ':    "ɭ",             // modifier letter small l with retroflex hook
+	'ᶪ':    "ᶅ",             // modifier letter small l with palatal hook
+	'ᶫ':    "ʟ",             // modifier letter small capital l
+	'ᶬ':    "ɱ",             // modifier letter small m with hook
+	'ᶭ':    "ɰ",             // modifier letter small turned m with long leg
+	'ᶮ':    "ɲ",             // modifier letter small n with left hook
+	'ᶯ':    "ɳ",             // modifier letter small n with retroflex hook
+	'ᶰ':    "ɴ",             // modifier letter small capital n
+	'ᶱ':    "ɵ",             // modifier letter small barred o
+	'ᶲ':    "ɸ",             // modifier letter small phi
+	'ᶳ':    "ʂ",             // modifier letter small s with hook
+	'ᶴ':    "ʃ",             // modifier letter small esh
+	'ᶵ':    "ƫ",             // modifier letter small t with palatal hook
+	'ᶶ':    "ʉ",             // modifier letter small u bar
+	'ᶷ':    "ʊ",             // modifier letter small upsilon
+	'ᶸ':    "ᴜ",             // modifier letter small capital u
+	'ᶹ':    "ʋ",             // modifier letter small v with hook
+	'ᶺ':    "ʌ",             // modifier letter small turned v
+	'ᶻ':    "z",             // modifier letter small z
+	'ᶼ':    "ʐ",             // modifier letter small z with retroflex hook
+	'ᶽ':    "ʑ",             // modifier letter small z with curl
+	'ᶾ':    "ʒ",             // modifier letter small ezh
+	'ᶿ':    "θ",             // modifier letter small theta
+	0x2000: " ",             // en quad
+	0x2001: " ",             // em quad
+	0x2002: " ",             // en space
+	0x2003: " ",             // em space
+	0x2004: " ",             // three-per-em space
+	0x2005: " ",             // four-per-em space
+	0x2006: " ",             // six-per-em space
+	0x2007: " ",             // figure space
+	0x2008: " ",             // punctuation space
+	0x2009: " ",             // thin space
+	0x200A: " ",             // hair space
+	'‑':    "‐",             // non-breaking hyphen
+	'‗':    " \u0333",       // double low line
+	'․':    ".",             // one dot leader
+	'‥':    "..",            // two dot leader
+	'…':    "...",           // horizontal ellipsis
+	0x202F: " ",             // narrow no-break space
+	'″':    "′′",            // double prime
+	'‴':    "′′′",           // triple prime
+	'‶':    "‵‵",            // reversed double prime
+	'‷':    "‵‵‵",           // reversed triple prime
+	'‼':    "!!",            // double exclamation mark
+	'‾':    " \u0305",       // overline
+	'⁇':    "??",            // double question mark
+	'⁈':    "?!",            // question exclamation mark
+	'⁉':    "!?",            // exclamation question mark
+	'⁗':    "′′′′",          // quadruple prime
+	0x205F: " ",             // medium mathematical space
+	'⁰':    "0",             // superscript zero
+	'ⁱ':    "i",             // superscript latin small letter i
+	'⁴':    "4",             // superscript four
+	'⁵':    "5",             // superscript five
+	'⁶':    "6",             // superscript six
+	'⁷':    "7",             // superscript seven
+	'⁸':    "8",             // superscript eight
+	'⁹':    "9",             // superscript nine
+	'⁺':    "+",             // superscript plus sign
+	'⁻':    "−",             // superscript minus
+	'⁼':    "=",             // superscript equals sign
+	'⁽':    "(",             // superscript left parenthesis
+	'⁾':    ")",             // superscript right parenthesis
+	'ⁿ':    "n",             // superscript latin small letter n
+	'₀':    "0",             // subscript zero
+	'₁':    "1",             // subscript one
+	'₂':    "2",             // subscript two
+	'₃':    "3",             // subscript three
+	'₄':    "4",             // subscript four
+	'₅':    "5",             // subscript five
+	'₆':    "6",             // subscript six
+	'₇':    "7",             // subscript seven
+	'₈':    "8",             // subscript eight
+	'₉':    "9",             // subscript nine
+	'₊':    "+",             // subscript plus sign
+	'₋':    "−",             // subscript minus
+	'₌':    "=",             // subscript equals sign
+	'₍':    "(",             // subscript left parenthesis
+	'₎':    ")",             // subscript right parenthesis
+	'ₐ':    "a",             // latin subscript small letter a
+	'ₑ':    "e",             // latin subscript small letter e
+	'ₒ':    "o",             // latin subscript small letter o
+	'ₓ':    "x",             // latin subscript small letter x
+	'ₔ':    "ə",             // latin subscript small letter schwa
+	'ₕ':    "h",             // latin subscript small letter h
+	'ₖ':    "k",             // latin subscript small letter k
+	'ₗ':    "l",             // latin subscript small letter l
+	'ₘ':    "m",             // latin subscript small letter m
+	'ₙ':    "n",             // latin subscript small letter n
+	'ₚ':    "p",             // latin subscript small letter p
+	'ₛ':    "s",             // latin subscript small letter s
+	'ₜ':    "t",             // latin subscript small letter t
+	'℀':    "a/c",           // account of
+	'℁':    "a/s",           // addressed to the subject
+	'ℂ':    "C",             // double-struck capital c
+	'℃':    "°C",            // degree celsius
+	'℅':    "c/o",           // care of
+	'℆':    "c/u",           // cada una
+	'ℇ':    "Ɛ",             // euler constant
+	'℉':    "°F",            // degree fahrenheit
+	'ℊ':    "g",             // script small g
+	'ℋ':    "H",             // script capital h
+	'ℌ':    "H",             // black-letter capital h
+	'ℍ':    "H",             // double-struck capital h
+	'ℎ':    "h",             // planck constant
+	'ℏ':    "ħ",             // planck constant over two pi
+	'ℐ':    "I",             // script capital i
+	'ℑ':    "I",             // black-letter capital i
+	'ℒ':    "L",             // script capital l
+	'ℓ':    "l",             // script small l
+	'ℕ':    "N",             // double-struck capital n
+	'№':    "No",            // numero sign
+	'ℙ':    "P",             // double-struck capital p
+	'ℚ':    "Q",             // double-struck capital q
+	'ℛ':    "R",             // script capital r
+	'ℜ':    "R",             // black-letter capital r
+	'ℝ':    "R",             // double-struck capital r
+	'℠':    "SM",            // service mark
+	'℡':    "TEL",           // telephone sign
+	'™':    "TM",            // trade mark sign
+	'ℤ':    "Z",             // double-struck capital z
+	'ℨ':    "Z",             // black-letter capital z
+	'ℬ':    "B",             // script capital b
+	'ℭ':    "C",             // black-letter capital c
+	'ℯ':    "e",             // script small e
+	'ℰ':    "E",             // script capital e
+	'ℱ':    "F",             // script capital f
+	'ℳ':    "M",             // script capital m
+	'ℴ':    "o",             // script small o
+	'ℵ':    "א",             // alef symbol
+	'ℶ':    "ב",             // bet symbol
+	'ℷ':    "ג",             // gimel symbol
+	'ℸ':    "ד",             // dalet symbol
+	'ℹ':    "i",             // information source
+	'℻':    "FAX",           // facsimile sign
+	'ℼ':    "π",             // double-struck small pi
+	'ℽ':    "γ",             // double-struck small gamma
+	'ℾ':    "Γ",             // double-struck capital gamma
+	'ℿ':    "Π",             // double-struck capital pi
+	'⅀':    "∑",             // double-struck n-ary summation
+	'ⅅ':    "D",             // double-struck italic capital d
+	'ⅆ':    "d",             // double-struck italic small d
+	'ⅇ':    "e",             // double-struck italic small e
+	'ⅈ':    "i",             // double-struck italic small i
+	'ⅉ':    "j",             // double-struck italic small j
+	'⅐':    "1⁄7",           // vulgar fraction one seventh
+	'⅑':    "1⁄9",           // vulgar fraction one ninth
+	'⅒':    "1⁄10",          // vulgar fraction one tenth
+	'⅓':    "1⁄3",           // vulgar fraction one third
+	'⅔':    "2⁄3",           // vulgar fraction two thirds
+	'⅕':    "1⁄5",           // vulgar fraction one fifth
+	'⅖':    "2⁄5",           // vulgar fraction two fifths
+	'⅗':    "3⁄5",           // vulgar fraction three fifths
+	'⅘':    "4⁄5",           // vulgar fraction four fifths
+	'⅙':    "1⁄6",           // vulgar fraction one sixth
+	'⅚':    "5⁄6",           // vulgar fraction five sixths
+	'⅛':    "1⁄8",           // vulgar fraction one eighth
+	'⅜':    "3⁄8",           // vulgar fraction three eighths
+	'⅝':    "5⁄8",           // vulgar fraction five eighths
+	'⅞':    "7⁄8",           // vulgar fraction seven eighths
+	'⅟':    "1⁄",            // fraction numerator one
+	'Ⅰ':    "I",             // roman numeral one
+	'Ⅱ':    "II",            // roman numeral two
+	'Ⅲ':    "III",           // roman numeral three
+	'Ⅳ':    "IV",            // roman numeral four
+	'Ⅴ':    "V",             // roman numeral five
+	'Ⅵ':    "VI",            // roman numeral six
+	'Ⅶ':    "VII",           // roman numeral seven
+	'Ⅷ':    "VIII",          // roman numeral eight
+	'Ⅸ':    "IX",            // roman numeral nine
+	'Ⅹ':    "X",             // roman numeral ten
+	'Ⅺ':    "XI",            // roman numeral eleven
+	'Ⅻ':    "XII",           // roman numeral twelve
+	'Ⅼ':    "L",             // roman numeral fifty
+	'Ⅽ':    "C",             // roman numeral one hundred
+	'Ⅾ':    "D",             // roman numeral five hundred
+	'Ⅿ':    "M",             // roman numeral one thousand
+	'ⅰ':    "i",             // small roman numeral one
+	'ⅱ':    "ii",            // small roman numeral two
+	'ⅲ':    "iii",           // small roman numeral three
+	'ⅳ':    "iv",            // small roman numeral four
+	'ⅴ':    "v",             // small roman numeral five
+	'ⅵ':    "vi",            // small roman numeral six
+	'ⅶ':    "vii",           // small roman numeral seven
+	'ⅷ':    "viii",          // small roman numeral eight
+	'ⅸ':    "ix",            // small roman numeral nine
+	'ⅹ':    "x",             // small roman numeral ten
+	'ⅺ':    "xi",            // small roman numeral eleven
+	'ⅻ':    "xii",           // small roman numeral twelve
+	'ⅼ':    "l",             // small roman numeral fifty
+	'ⅽ':    "c",             // small roman numeral one hundred
+	'ⅾ':    "d",             // small roman numeral five hundred
+	'ⅿ':    "m",             // small roman numeral one thousand
+	'↉':    "0⁄3",           // vulgar fraction zero thirds
+	'①':    "1",             // circled digit one
+	'②':    "2",             // circled digit two
+	'③':    "3",             // circled digit three
+	'④':    "4",             // circled digit four
+	'⑤':    "5",             // circled digit five
+	'⑥':    "6",             // circled digit six
+	'⑦':    "7",             // circled digit seven
+	'⑧':    "8",             // circled digit eight
+	'⑨':    "9",             // circled digit nine
+	'⑩':    "10",            // circled number ten
+	'⑪':    "11",            // circled number eleven
+	'⑫':    "12",            // circled number twelve
+	'⑬':    "13",            // circled number thirteen
+	'⑭':    "14",            // circled number fourteen
+	'⑮':    "15",            // circled number fifteen
+	'⑯':    "16",            // circled number sixteen
+	'⑰':    "17",            // circled number seventeen
+	'⑱':    "18",            // circled number eighteen
+	'⑲':    "19",            // circled number nineteen
+	'⑳':    "20",            // circled number twenty
+	'⑴':    "(1)",           // parenthesized digit one
+	'⑵':    "(2)",           // parenthesized digit two
+	'⑶':    "(3)",           // parenthesized digit three
+	'⑷':    "(4)",           // parenthesized digit four
+	'⑸':    "(5)",           // parenthesized digit five
+	'⑹':    "(6)",           // parenthesized digit six
+	'⑺':    "(7)",           // parenthesized digit seven
+	'⑻':    "(8)",           // parenthesized digit eight
+	'⑼':    "(9)",           // parenthesized digit nine
+	'⑽':    "(10)",          // parenthesized number ten
+	'⑾':    "(11)",          // parenthesized number eleven
+	'⑿':    "(12)",          // parenthesized number twelve
+	'⒀':    "(13)",          // parenthesized number thirteen
+	'⒁':    "(14)",          // parenthesized number fourteen
+	'⒂':    "(15)",          // parenthesized number fifteen
+	'⒃':    "(16)",          // parenthesized number sixteen
+	'⒄':    "(17)",          // parenthesized number seventeen
+	'⒅':    "(18)",          // parenthesized number eighteen
+	'⒆':    "(19)",          // parenthesized number nineteen
+	'⒇':    "(20)",          // parenthesized number twenty
+	'⒈':    "1.",            // digit one full stop
+	'⒉':    "2.",            // digit two full stop
+	'⒊':    "3.",            // digit three full stop
+	'⒋':    "4.",            // digit four full stop
+	'⒌':    "5.",            // digit five full stop
+	'⒍':    "6.",            // digit six full stop
+	'⒎':    "7.",            // digit seven full stop
+	'⒏':    "8.",            // digit eight full stop
+	'⒐':    "9.",            // digit nine full stop
+	'⒑':    "10.",           // number ten full stop
+	'⒒':    "11.",           // number eleven full stop
+	'⒓':    "12.",           // number twelve full stop
+	'⒔':    "13.",           // number thirteen full stop
+	'⒕':    "14.",           // number fourteen full stop
+	'⒖':    "15.",           // number fifteen full stop
+	'⒗':    "16.",           // number sixteen full stop
+	'⒘':    "17.",           // number seventeen full stop
+	'⒙':    "18.",           // number eighteen full stop
+	'⒚':    "19.",           // number nineteen full stop
+	'⒛':    "20.",           // number twenty full stop
+	'⒜':    "(a)",           // parenthesized latin small letter a
+	'⒝':    "(b)",           // parenthesized latin small letter b
+	'⒞':    "(c)",           // parenthesized latin small letter c
+	'⒟':    "(d)",           // parenthesized latin small letter d
+	'⒠':    "(e)",           // parenthesized latin small letter e
+	'⒡':    "(f)",           // parenthesized latin small letter f
+	'⒢':    "(g)",           // parenthesized latin small letter g
+	'⒣':    "(h)",           // parenthesized latin small letter h
+	'⒤':    "(i)",           // parenthesized latin small letter i
+	'⒥':    "(j)",           // parenthesized latin small letter j
+	'⒦':    "(k)",           // parenthesized latin small letter k
+	'⒧':    "(l)",           // parenthesized latin small letter l
+	'⒨':    "(m)",           // parenthesized latin small letter m
+	'⒩':    "(n)",           // parenthesized latin small letter n
+	'⒪':    "(o)",           // parenthesized latin small letter o
+	'⒫':    "(p)",           // parenthesized latin small letter p
+	'⒬':    "(q)",           // parenthesized latin small letter q
+	'⒭':    "(r)",           // parenthesized latin small letter r
+	'⒮':    "(s)",           // parenthesized latin small letter s
+	'⒯':    "(t)",           // parenthesized latin small letter t
+	'⒰':    "(u)",           // parenthesized latin small letter u
+	'⒱':    "(v)",           // parenthesized latin small letter v
+	'⒲':    "(w)",           // parenthesized latin small letter w
+	'⒳':    "(x)",           // parenthesized latin small letter x
+	'⒴':    "(y)",           // parenthesized latin small letter y
+	'⒵':    "(z)",           // parenthesized latin small letter z
+	'Ⓐ':    "A",             // circled latin capital letter a
+	'Ⓑ':    "B",             // circled latin capital letter b
+	'Ⓒ':    "C",             // circled latin capital letter c
+	'Ⓓ':    "D",             // circled latin capital letter d
+	'Ⓔ':    "E",             // circled latin capital letter e
+	'Ⓕ':    "F",             // circled latin capital letter f
+	'Ⓖ':    "G",             // circled latin capital letter g
+	'Ⓗ':    "H",             // circled latin capital letter h
+	'Ⓘ':    "I",             // circled latin capital letter i
+	'Ⓙ':    "J",             // circled latin capital letter j
+	'Ⓚ':    "K",             // circled latin capital letter k
+	'Ⓛ':    "L",             // circled latin capital letter l
+	'Ⓜ':    "M",             // circled latin capital letter m
+	'Ⓝ':    "N",             // circled latin capital letter n
+	'Ⓞ':    "O",             // circled latin capital letter o
+	'Ⓟ':    "P",             // circled latin capital letter p
+	'Ⓠ':    "Q",             // circled latin capital letter q
+	'Ⓡ':    "R",             // circled latin capital letter r
+	'Ⓢ':    "S",             // circled latin capital letter s
+	'Ⓣ':    "T",             // circled latin capital letter t
+	'Ⓤ':    "U",             // circled latin capital letter u
+	'Ⓥ':    "V",             // circled latin capital letter v
+	'Ⓦ':    "W",             // circled latin capital letter w
+	'Ⓧ':    "X",             // circled latin capital letter x
+	'Ⓨ':    "Y",             // circled latin capital letter y
+	'Ⓩ':    "Z",             // circled latin capital letter z
+	'ⓐ':    "a",             // circled latin small letter a
+	'ⓑ':    "b",             // circled latin small letter b
+	'ⓒ':    "c",             // circled latin small letter c
+	'ⓓ':    "d",             // circled latin small letter d
+	'ⓔ':    "e",             // circled latin small letter e
+	'ⓕ':    "f",             // circled latin small letter f
+	'ⓖ':    "g",             // circled latin small letter g
+	'ⓗ':    "h",             // circled latin small letter h
+	'ⓘ':    "i",             // circled latin small letter i
+	'ⓙ':    "j",             // circled latin small letter j
+	'ⓚ':    "k",             // circled latin small letter k
+	'ⓛ':    "l",             // circled latin small letter l
+	'ⓜ':    "m",             // circled latin small letter m
+	'ⓝ':    "n",             // circled latin small letter n
+	'ⓞ':    "o",             // circled latin small letter o
+	'ⓟ':    "p",             // circled latin small letter p
+	'ⓠ':    "q",             // circled latin small letter q
+	'ⓡ':    "r",             // circled latin small letter r
+	'ⓢ':    "s",             // circled latin small letter s
+	'ⓣ':    "t",             // circled latin small letter t
+	'ⓤ':    "u",             // circled latin small letter u
+	'ⓥ':    "v",             // circled latin small letter v
+	'ⓦ':    "w",             // circled latin small letter w
+	'ⓧ':    "x",             // circled latin small letter x
+	'ⓨ':    "y",             // circled latin small letter y
+	'ⓩ':    "z",             // circled latin small letter z
+	'⓪':    "0",             // circled digit zero
+	'ﬀ':    "ff",            // latin small ligature ff
+	'ﬁ':    "fi",            // latin small ligature fi
+	'ﬂ':    "fl",            // latin small ligature fl
+	'ﬃ':    "ffi",           // latin small ligature ffi
+	'ﬄ':    "ffl",           // latin small ligature ffl
+	'ﬅ':    "st",            // latin small ligature long s t
+	'ﬆ':    "st",            // latin small ligature st
+}
+
+// caseFolding are the case foldings of the runes that aren't their lowercase form (unicode.ToLower),
+// e.g. "ß" is "ss" and "ς" is "σ".
+var caseFolding = map[rune]string{
+	'µ':    "μ",             // micro sign
+	'ß':    "ss",            // latin small letter sharp s
+	'İ':    "i\u0307",       // latin capital letter i with dot above
+	'ŉ':    "ʼn",            // latin small letter n preceded by apostrophe
+	'ſ':    "s",             // latin small letter long s
+	'ǰ':    "j\u030C",       // latin small letter j with caron
+	0x0345: "ι",             // combining greek ypogegrammeni
+	'ΐ':    "ι\u0308\u0301", // greek small letter iota with dialytika and tonos
+	'ΰ':    "υ\u0308\u0301", // greek small letter upsilon with dialytika and tonos
+	'ς':    "σ",             // greek small letter final sigma
+	'ϐ':    "β",             // greek beta symbol
+	'ϑ':    "θ",             // greek theta symbol
+	'ϕ':    "φ",             // greek phi symbol
+	'ϖ':    "π",             // greek pi symbol
+	'ϰ':    "κ",             // greek kappa symbol
+	'ϱ':    "ρ",             // greek rho symbol
+	'ϵ':    "ε",             // greek lunate epsilon symbol
+	'և':    "եւ",            // armenian small ligature ech yiwn
+	'ᏸ':    "Ᏸ",             // cherokee small letter ye
+	'ᏹ':    "Ᏹ",             // cherokee small letter yi
+	'ᏺ':    "Ᏺ",             // cherokee small letter yo
+	'ᏻ':    "Ᏻ",             // cherokee small letter yu
+	'ᏼ':    "Ᏼ",             // cherokee small letter yv
+	'ᏽ':    "Ᏽ",             // cherokee small letter mv
+	'ᲀ':    "в",             // cyrillic small letter rounded ve
+	'ᲁ':    "д",             // cyrillic small letter long-legged de
+	'ᲂ':    "о",             // cyrillic small letter narrow o
+	'ᲃ':    "с",             // cyrillic small letter wide es
+	'ᲄ':    "т",             // cyrillic small letter tall te
+	'ᲅ':    "т",             // cyrillic small letter three-legged te
+	'ᲆ':    "ъ",             // cyrillic small letter tall hard sign
+	'ᲇ':    "ѣ",             // cyrillic small letter tall yat
+	'ᲈ':    "ꙋ",             // cyrillic small letter unblended uk
+	'ẖ':    "h\u0331",       // latin small letter h with line below
+	'ẗ':    "t\u0308",       // latin small letter t with diaeresis
+	'ẘ':    "w\u030A",       // latin small letter w with ring above
+	'ẙ':    "y\u030A",       // latin small letter y with ring above
+	'ẚ':    "aʾ",            // latin small letter a with right half ring
+	'ẛ':    "ṡ",             // latin small letter long s with dot above
+	'ẞ':    "ss",            // latin capital letter sharp s
+	'ὐ':    "υ\u0313",       // greek small letter upsilon with psili
+	'ὒ':    "υ\u0313\u0300", // greek small letter upsilon with psili and varia
+	'ὔ':    "υ\u0313\u0301", // greek small letter upsilon with psili and oxia
+	'ὖ':    "υ\u0313\u0342", // greek small letter upsilon with psili and perispomeni
+	'ᾀ':    "ἀι",            // greek small letter alpha with psili and ypogegrammeni
+	'ᾁ':    "ἁι",            // greek small letter alpha with dasia and ypogegrammeni
+	'ᾂ':    "ἂι",            // greek small letter alpha with psili and varia and ypogegrammeni
+	'ᾃ':    "ἃι",            // greek small letter alpha with dasia and varia and ypogegrammeni
+	'ᾄ':    "ἄι",            // greek small letter alpha with psili and oxia and ypogegrammeni
+	'ᾅ':    "ἅι",            // greek small letter alpha with dasia and oxia and ypogegrammeni
+	'ᾆ':    "ἆι",            // greek small letter alpha with psili and perispomeni and ypogegrammeni
+	'ᾇ':    "ἇι",            // greek small letter alpha with dasia and perispomeni and ypogegrammeni
+	'ᾈ':    "ἀι",            // greek capital letter alpha with psili and prosgegrammeni
+	'ᾉ':    "ἁι",            // greek capital letter alpha with dasia and prosgegrammeni
+	'ᾊ':    "ἂι",            // greek capital letter alpha with psili and varia and prosgegrammeni
+	'ᾋ':    "ἃι",            // greek capital letter alpha with dasia and varia and prosgegrammeni
+	'ᾌ':    "ἄι",            // greek capital letter alpha with psili and oxia and prosgegrammeni
+	'ᾍ':    "ἅι",            // greek capital letter alpha with dasia and oxia and prosgegrammeni
+	'ᾎ':    "ἆι",            // greek capital letter alpha with psili and perispomeni and prosgegrammeni
+	'ᾏ':    "ἇι",            // greek capital letter alpha with dasia and perispomeni and prosgegrammeni
+	'ᾐ':    "ἠι",            // greek small letter eta with psili and ypogegrammeni
+	'ᾑ':    "ἡι",            // greek small letter eta with dasia and ypogegrammeni
+	'ᾒ':    "ἢι",            // greek small letter eta with psili and varia and ypogegrammeni
+	'ᾓ':    "ἣι",            // greek small letter eta with dasia and varia and ypogegrammeni
+	'ᾔ':    "ἤι",            // greek small letter eta with psili and oxia and ypogegrammeni
+	'ᾕ':    "ἥι",            // greek small letter eta with dasia and oxia and ypogegrammeni
+	'ᾖ':    "ἦι",            // greek small letter eta with psili and perispomeni and ypogegrammeni
+	'ᾗ':    "ἧι",            // greek small letter eta with dasia and perispomeni and ypogegrammeni
+	'ᾘ':    "ἠι",            // greek capital letter eta with psili and prosgegrammeni
+	'ᾙ':    "ἡι",            // greek capital letter eta with dasia and prosgegrammeni
+	'ᾚ':    "ἢι",            // greek capital letter eta with psili and varia and prosgegrammeni
+	'ᾛ':    "ἣι",            // greek capital letter eta with dasia and varia and prosgegrammeni
+	'ᾜ':    "ἤι",            // greek capital letter eta with psili and oxia and prosgegrammeni
+	'ᾝ':    "ἥι",            // greek capital letter eta with dasia and oxia and prosgegrammeni
+	'ᾞ':    "ἦι",            // greek capital letter eta with psili and perispomeni and prosgegrammeni
+	'ᾟ':    "ἧι",            // greek capital letter eta with dasia and perispomeni and prosgegrammeni
+	'ᾠ':    "ὠι",            // greek small letter omega with psili and ypogegrammeni
+	'ᾡ':    "ὡι",            // greek small letter omega with dasia and ypogegrammeni
+	'ᾢ':    "ὢι",            // greek small letter omega with psili and varia and ypogegrammeni
+	'ᾣ':    "ὣι",            // greek small letter omega with dasia and varia and ypogegrammeni
+	'ᾤ':    "ὤι",            // greek small letter omega with psili and oxia and ypogegrammeni
+	'ᾥ':    "ὥι",            // greek small letter omega with dasia and oxia and ypogegrammeni
+	'ᾦ':    "ὦι",            // greek small letter omega with psili and perispomeni and ypogegrammeni
+	'ᾧ':    "ὧι",            // greek small letter omega with dasia and perispomeni and ypogegrammeni
+	'ᾨ':    "ὠι",            // greek capital letter omega with psili and prosgegrammeni
+	'ᾩ':    "ὡι",            // greek capital letter omega with dasia and prosgegrammeni
+	'ᾪ':    "ὢι",            // greek capital letter omega with psili and varia and prosgegrammeni
+	'ᾫ':    "ὣι",            // greek capital letter omega with dasia and varia and prosgegrammeni
+	'ᾬ':    "ὤι",            // greek capital letter omega with psili and oxia and prosgegrammeni
+	'ᾭ':    "ὥι",            // greek capital letter omega with dasia and oxia and prosgegrammeni
+	'ᾮ':    "ὦι",            // greek capital letter omega with psili and perispomeni and prosgegrammeni
+	'ᾯ':    "ὧι",            // greek capital letter omega with dasia and perispomeni and prosgegrammeni
+	'ᾲ':    "ὰι",            // greek small letter alpha with varia and ypogegrammeni
+	'ᾳ':    "αι",            // greek small letter alpha with ypogegrammeni
+	'ᾴ':    "άι",            // greek small letter alpha with oxia and ypogegrammeni
+	'ᾶ':    "α\u0342",       // greek small letter alpha with perispomeni
+	'ᾷ':    "α\u0342ι",      // greek small letter alpha with perispomeni and ypogegrammeni
+	'ᾼ':    "αι",            // greek capital letter alpha with prosgegrammeni
+	'ι':    "ι",             // greek prosgegrammeni
+	'ῂ':    "ὴι",            // greek small letter eta with varia and ypogegrammeni
+	'ῃ':    "ηι",            // greek small letter eta with ypogegrammeni
+	'ῄ':    "ήι",            // greek small letter eta with oxia and ypogegrammeni
+	'ῆ':    "η\u0342",       // greek small letter eta with perispomeni
+	'ῇ':    "η\u0342ι",      // greek small letter eta with perispomeni and ypogegrammeni
+	'ῌ':    "ηι",            // greek capital letter eta with prosgegrammeni
+	'ῒ':    "ι\u0308\u0300", // greek small letter iota with dialytika and varia
+	'ΐ':    "ι\u0308\u0301", // greek small letter iota with dialytika and oxia
+	'ῖ':    "ι\u0342",       // greek small letter iota with perispomeni
+	'ῗ':    "ι\u0308\u0342", // greek small letter iota with dialytika and perispomeni
+	'ῢ':    "υ\u0308\u0300", // greek small letter upsilon with dialytika and varia
+	'ΰ':    "υ\u0308\u0301", // greek small letter upsilon with dialytika and oxia
+	'ῤ':    "ρ\u0313",       // greek small letter rho with psili
+	'ῦ':    "υ\u0342",       // greek small letter upsilon with perispomeni
+	'ῧ':    "υ\u0308\u0342", // greek small letter upsilon with dialytika and perispomeni
+	'ῲ':    "ὼι",            // greek small letter omega with varia and ypogegrammeni
+	'ῳ':    "ωι",            // greek small letter omega with ypogegrammeni
+	'ῴ':    "ώι",            // greek small letter omega with oxia and ypogegrammeni
+	'ῶ':    "ω\u0342",       // greek small letter omega with perispomeni
+	'ῷ':    "ω\u0342ι",      // greek small letter omega with perispomeni and ypogegrammeni
+	'ῼ':    "ωι",            // greek capital letter omega with prosgegrammeni
+	'ꭰ':    "Ꭰ",             // cherokee small letter a
+	'ꭱ':    "Ꭱ",             // cherokee small letter e
+	'ꭲ':    "Ꭲ",             // cherokee small letter i
+	'ꭳ':    "Ꭳ",             // cherokee small letter o
+	'ꭴ':    "Ꭴ",             // cherokee small letter u
+	'ꭵ':    "Ꭵ",             // cherokee small letter v
+	'ꭶ':    "Ꭶ",             // cherokee small letter ga
+	'ꭷ':    "Ꭷ",             // cherokee small letter ka
+	'ꭸ':    "Ꭸ",             // cherokee small letter ge
+	'ꭹ':    "Ꭹ",             // cherokee small letter gi
+	'ꭺ':    "Ꭺ",             // cherokee small letter go
+	'ꭻ':    "Ꭻ",             // cherokee small letter gu
+	'ꭼ':    "Ꭼ",             // cherokee small letter gv
+	'ꭽ':    "Ꭽ",             // cherokee small letter ha
+	'ꭾ':    "Ꭾ",             // cherokee small letter he
+	'ꭿ':    "Ꭿ",             // cherokee small letter hi
+	'ꮀ':    "Ꮀ",             // cherokee small letter ho
+	'ꮁ':    "Ꮁ",             // cherokee small letter hu
+	'ꮂ':    "Ꮂ",             // cherokee small letter hv
+	'ꮃ':    "Ꮃ",             // cherokee small letter la
+	'ꮄ':    "Ꮄ",             // cherokee small letter le
+	'ꮅ':    "Ꮅ",             // cherokee small letter li
+	'ꮆ':    "Ꮆ",             // cherokee small letter lo
+	'ꮇ':    "Ꮇ",             // cherokee small letter lu
+	'ꮈ':    "Ꮈ",             // cherokee small letter lv
+	'ꮉ':    "Ꮉ",             // cherokee small letter ma
+	'ꮊ':    "Ꮊ",             // cherokee small letter me
+	'ꮋ':    "Ꮋ",             // cherokee small letter mi
+	'ꮌ':    "Ꮌ",             // cherokee small letter mo
+	'ꮍ':    "Ꮍ",             // cherokee small letter mu
+	'ꮎ':    "Ꮎ",             // cherokee small letter na
+	'ꮏ':    "Ꮏ",             // cherokee small letter hna
+	'ꮐ':    "Ꮐ",             // cherokee small letter nah
+	'ꮑ':    "Ꮑ",             // cherokee small letter ne
+	'ꮒ':    "Ꮒ",             // cherokee small letter ni
+	'ꮓ':    "Ꮓ",             // cherokee small letter no
+	'ꮔ':    "Ꮔ",             // cherokee small letter nu
+	'ꮕ':    "Ꮕ",             // cherokee small letter nv
+	'ꮖ':    "Ꮖ",             // cherokee small letter qua
+	'ꮗ':    "Ꮗ",             // cherokee small letter que
+	'ꮘ':    "Ꮘ",             // cherokee small letter qui
+	'ꮙ':    "Ꮙ",             // cherokee small letter quo
+	'ꮚ':    "Ꮚ",             // cherokee small letter quu
+	'ꮛ':    "Ꮛ",             // cherokee small letter quv
+	'ꮜ':    "Ꮜ",             // cherokee small letter sa
+	'ꮝ':    "Ꮝ",             // cherokee small letter s
+	'ꮞ':    "Ꮞ",             // cherokee small letter se
+	'ꮟ':    "Ꮟ",             // cherokee small letter si
+	'ꮠ':    "Ꮠ",             // cherokee small letter so
+	'ꮡ':    "Ꮡ",             // cherokee small letter su
+	'ꮢ':    "Ꮢ",             // cherokee small letter sv
+	'ꮣ':    "Ꮣ",             // cherokee small letter da
+	'ꮤ':    "Ꮤ",             // cherokee small letter ta
+	'ꮥ':    "Ꮥ",             // cherokee small letter de
+	'ꮦ':    "Ꮦ",             // cherokee small letter te
+	'ꮧ':    "Ꮧ",             // cherokee small letter di
+	'ꮨ':    "Ꮨ",             // cherokee small letter ti
+	'ꮩ':    "Ꮩ",             // cherokee small letter do
+	'ꮪ':    "Ꮪ",             // cherokee small letter du
+	'ꮫ':    "Ꮫ",             // cherokee small letter dv
+	'ꮬ':    "Ꮬ",             // cherokee small letter dla
+	'ꮭ':    "Ꮭ",             // cherokee small letter tla
+	'ꮮ':    "Ꮮ",             // cherokee small letter tle
+	'ꮯ':    "Ꮯ",             // cherokee small letter tli
+	'ꮰ':    "Ꮰ",             // cherokee small letter tlo
+	'ꮱ':    "Ꮱ",             // cherokee small letter tlu
+	'ꮲ':    "Ꮲ",             // cherokee small letter tlv
+	'ꮳ':    "Ꮳ",             // cherokee small letter tsa
+	'ꮴ':    "Ꮴ",             // cherokee small letter tse
+	'ꮵ':    "Ꮵ",             // cherokee small letter tsi
+	'ꮶ':    "Ꮶ",             // cherokee small letter tso
+	'ꮷ':    "Ꮷ",             // cherokee small letter tsu
+	'ꮸ':    "Ꮸ",             // cherokee small letter tsv
+	'ꮹ':    "Ꮹ",             // cherokee small letter wa
+	'ꮺ':    "Ꮺ",             // cherokee small letter we
+	'ꮻ':    "Ꮻ",             // cherokee small letter wi
+	'ꮼ':    "Ꮼ",             // cherokee small letter wo
+	'ꮽ':    "Ꮽ",             // cherokee small letter wu
+	'ꮾ':    "Ꮾ",             // cherokee small letter wv
+	'ꮿ':    "Ꮿ",             // cherokee small letter ya
+	'ﬀ':    "ff",            // latin small ligature ff
+	'ﬁ':    "fi",            // latin small ligature fi
+	'ﬂ':    "fl",            // latin small ligature fl
+	'ﬃ':    "ffi",           // latin small ligature ffi
+	'ﬄ':    "ffl",           // latin small ligature ffl
+	'ﬅ':    "st",            // latin small ligature long s t
+	'ﬆ':    "st",            // latin small ligature st
+	'ﬓ':    "մն",            // armenian small ligature men now
+	'ﬔ':    "մե",            // armenian small ligature men ech
+	'ﬕ':    "մի",            // armenian small ligature men ini
+	'ﬖ':    "վն",            // armenian small ligature vew now
+	'ﬗ':    "մխ",            // armenian small ligature men xeh
+}
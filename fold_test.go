@@ -0,0 +1,158 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		folding  Folding
+		input    string
+		lower    bool
+		expected string
+	}{
+		{name: "No folding", folding: 0, input: "Città", lower: true, expected: "città"},
+		{name: "No folding keeps the case", folding: 0, input: "Città", lower: false, expected: "Città"},
+		{name: "Marks", folding: FoldMarks, input: "Città Ærø", lower: true, expected: "citta æro"},
+		{name: "Marks keep the case", folding: FoldMarks, input: "ÀÉÎ", lower: false, expected: "AEI"},
+		{name: "Combining marks", folding: FoldMarks, input: "café", lower: true, expected: "cafe"},
+		{name: "Strokes", folding: FoldMarks, input: "Łódź Øl", lower: true, expected: "lodz ol"},
+		{name: "Case folding", folding: FoldCase, input: "Straße ΣΊΣΥΦΟΣ", lower: true, expected: "strasse σίσυφοσ"},
+		{name: "Case folding keeps the case", folding: FoldCase, input: "Straße", lower: false, expected: "Straße"},
+		{name: "Compatibility", folding: FoldCompat, input: "ﬁle² Ⅻ", lower: true, expected: "file2 xii"},
+		{name: "Ligatures", folding: FoldCompat, input: "Æon œuvre", lower: false, expected: "AEon oeuvre"},
+		{name: "Turkish", folding: FoldTurkish, input: "IŞIK İstanbul", lower: true, expected: "ışık istanbul"},
+		{name: "Turkish ASCII", folding: FoldTurkish, input: "ISPARTA", lower: true, expected: "ısparta"},
+		{name: "All", folding: FoldAll, input: "Ŝtraße ﬁ", lower: true, expected: "strasse fi"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			syn := syntax{folding: tc.folding}
+			if result := syn.normalize(tc.input, tc.lower); result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+
+			at := make([]int, len(tc.input))
+			for i := range at {
+				at[i] = i
+			}
+			result, offsets := syn.normalizeAt(tc.input, at, tc.lower)
+			if result != tc.expected || len(offsets) != len(result) {
+				t.Errorf("normalizeAt: expected %q, got %q with the offsets %v", tc.expected, result, offsets)
+			}
+		})
+	}
+}
+
+func TestFindFolding(t *testing.T) {
+	source := []string{
+		"Città del Sole",
+		"Straße Köln",
+		"STRASSE KOLN",
+		"İstanbul",
+		"ISPARTA",
+		"ﬁle Æon",
+		"citta",
+	}
+
+	testCases := []struct {
+		name     string
+		query    string
+		opts     []Option
+		expected []int
+	}{
+		{name: "No folding", query: "citta", expected: []int{6}},
+		{name: "Marks", query: "citta", opts: []Option{WithFolding(FoldMarks)}, expected: []int{0, 6}},
+		{name: "Marks in the query", query: "città", opts: []Option{WithFolding(FoldMarks)}, expected: []int{0, 6}},
+		{name: "Case folding", query: "strasse", opts: []Option{WithFolding(FoldCase)}, expected: []int{1, 2}},
+		{name: "Sensitive query", query: "Straße", opts: []Option{WithFolding(FoldAll)}, expected: []int{1}},
+		{name: "Compatibility", query: "file aeon", opts: []Option{WithFolding(FoldCompat)}, expected: []int{5}},
+		{name: "Turkish", query: "istanbul", opts: []Option{WithFolding(FoldTurkish)}, expected: []int{3}},
+		{name: "Turkish dotless", query: "ısparta", opts: []Option{WithFolding(FoldTurkish)}, expected: []int{4}},
+		{name: "Turkish dotted", query: "isparta", opts: []Option{WithFolding(FoldTurkish)}, expected: []int{}},
+		{name: "Filters", query: "*koln strasse", opts: []Option{WithFolding(FoldAll)}, expected: []int{1, 2}},
+		{name: "Sensitive filter", query: "strasse *Koln", opts: []Option{WithFolding(FoldAll)}, expected: []int{1}},
+		{name: "Insensitive filter with a sensitive text", query: "Strasse *köln", opts: []Option{WithFolding(FoldAll)}, expected: []int{}},
+		{name: "Insensitive filter folding the line", query: "STRASSE $köln", opts: []Option{WithFolding(FoldAll)}, expected: []int{2}},
+		{name: "Regex", query: "?^citta", opts: []Option{WithFolding(FoldMarks)}, expected: []int{0, 6}},
+		{name: "fzf", query: "'citta ^cit", opts: []Option{WithFolding(FoldMarks), WithSyntax(SyntaxFZF)}, expected: []int{0, 6}},
+		{name: "Terms", query: "sole citta", opts: []Option{WithFolding(FoldMarks), WithSyntax(SyntaxTerms)}, expected: []int{0}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := make([]int, 0)
+			for _, m := range Find(tc.query, source, tc.opts...) {
+				result = append(result, m.Position)
+			}
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Expected lines %v, got %v", tc.expected, result)
+			}
+
+			// every entry point must fold in the same way
+			expected := Find(tc.query, source, tc.opts...)
+			if m := NewCorpus(source).Find(tc.query, tc.opts...); !reflect.DeepEqual(m, expected) {
+				t.Errorf("Corpus.Find: expected %v, got %v", expected, m)
+			}
+			if m := NewArena(source).Find(tc.query, tc.opts...); !reflect.DeepEqual(m, expected) {
+				t.Errorf("Arena.Find: expected %v, got %v", expected, m)
+			}
+			if m := NewCorpus(source).LevenshteinFind(tc.query, tc.opts...); !reflect.DeepEqual(m, LevenshteinFind(tc.query, source, tc.opts...)) {
+				t.Errorf("Corpus.LevenshteinFind: expected the same matches of LevenshteinFind, got %v", m)
+			}
+			for _, m := range expected {
+				if e := Explain(tc.query, source[m.Position], tc.opts...); e.Score != m.Score {
+					t.Errorf("Explain: expected %d for line %d, got %+v", m.Score, m.Position, e)
+				}
+			}
+		})
+	}
+}
+
+func TestFoldingPositions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		source   string
+		opts     []Option
+		expected []int
+	}{
+		{name: "Marks", query: "citta", source: "La Città", opts: []Option{WithFolding(FoldMarks)}, expected: []int{3, 4, 5, 6, 7}},
+		{name: "Expanded rune", query: "strasse", source: "Straße", opts: []Option{WithFolding(FoldAll)}, expected: []int{0, 1, 2, 3, 4, 6}},
+		{name: "Ligature", query: "fe", source: "ﬁle", opts: []Option{WithFolding(FoldCompat)}, expected: []int{0, 4}},
+		{name: "Combining mark", query: "cafe", source: "café au lait", opts: []Option{WithFolding(FoldMarks)}, expected: []int{0, 1, 2, 3}},
+		{name: "Filters", query: "^straße koln", source: "Straße Köln", opts: []Option{WithFolding(FoldAll)}, expected: []int{8, 9, 11, 12}},
+		{name: "Sensitive filter", query: "$Köln strasse", source: "Straße Köln", opts: []Option{WithFolding(FoldAll)}, expected: []int{0, 1, 2, 3, 4, 6}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := Explain(tc.query, tc.source, tc.opts...)
+			if !reflect.DeepEqual(e.Positions, tc.expected) {
+				t.Errorf("Expected the positions %v, got %+v", tc.expected, e)
+			}
+			if score := MatchScore(tc.query, tc.source, tc.opts...); e.Score != score {
+				t.Errorf("Expected score %d (MatchScore), got %d", score, e.Score)
+			}
+		})
+	}
+}
+
+func TestFoldingKeys(t *testing.T) {
+	source := []string{"città", "citta"}
+
+	c := NewCache(100)
+	c.Find(1, "citta", source)
+	if m := c.Find(1, "citta", source, WithFolding(FoldMarks)); len(m) != 2 {
+		t.Errorf("Expected the cache to tell the foldings apart, got %v", m)
+	}
+
+	in := NewIncremental(source)
+	in.Find("cit")
+	if m := in.Find("citta", WithFolding(FoldMarks)); len(m) != 2 {
+		t.Errorf("Expected the incremental search to match both lines, got %v", m)
+	}
+}
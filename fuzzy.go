@@ -2,64 +2,126 @@ package fuzzy
 
 import (
 	"regexp"
-	"runtime"
 	"slices"
 	"strings"
-	"sync"
+	"sync/atomic"
+	"time"
 	"unicode"
 	"unicode/utf8"
 )
 
 // ChunkFind performs a parallelized fuzzy search using the standard matching algorithm.
 // It splits the source slice into chunks and processes them concurrently for better performance
-// on large datasets, then combines the results.
-func ChunkFind(query string, source []string) []Match {
-	return chunkFind(query, source, Find)
+// on large datasets, then combines the results in the same order as Find.
+//
+// The parallelism can be configured with the WithWorkers, WithMinChunkSize, WithBatchSize and WithPool options,
+// and the statistics of the search can be collected with WithStats and WithStatsHook.
+func ChunkFind(query string, source []string, opts ...Option) []Match {
+	o := newOptions(opts)
+	q := newQuery(query, &o)
+	return chunkFind(&q, source, standard, &o)
 }
 
 // ChunkLevenshteinFind performs a parallelized fuzzy search using the Levenshtein distance algorithm.
 // It splits the source slice into chunks and processes them concurrently for better performance
-// on large datasets, then combines the results.
-func ChunkLevenshteinFind(query string, source []string) []Match {
-	return chunkFind(query, source, LevenshteinFind)
+// on large datasets, then combines the results in the same order as LevenshteinFind.
+//
+// The parallelism can be configured with the WithWorkers, WithMinChunkSize, WithBatchSize and WithPool options,
+// and the statistics of the search can be collected with WithStats and WithStatsHook.
+func ChunkLevenshteinFind(query string, source []string, opts ...Option) []Match {
+	o := newOptions(opts)
+	q := newQuery(query, &o)
+	return chunkFind(&q, source, levenshtein, &o)
 }
 
-// chunkFind is a helper function that splits the source into chunks and runs the algorithm on each chunk.
-func chunkFind(query string, source []string, algo func(q string, s []string) []Match) []Match {
-	cpu := min(4, runtime.NumCPU()/2)
+// chunkFind is a helper function that runs the algorithm on the source using multiple goroutines.
+// The source is split in small batches that the goroutines pull from a shared cursor as soon as
+// they are free, so that a region of expensive lines (e.g. very long ones) doesn't slow down
+// the whole search waiting for a single goroutine.
+// The matches of every batch are stored in their own slot and joined in batch order at the end,
+// so the result has the same order as the one of the sequential search.
+func chunkFind(q *query, source []string, algo algorithm, o *options) []Match {
+	return chunkSearch(q, source, algo, o, time.Time{}).Matches
+}
 
-	if cpu <= 1 || len(source) <= cpu*500 {
-		return algo(query, source)
+// chunkSearch is the implementation of chunkFind, stopping the search at the deadline (if not zero).
+// The calling goroutine scans the batches too, and the other workers are skipped if the pool has
+// no free goroutine, so a busy pool never blocks the search: the batches are taken from a shared cursor,
+// so any number of workers scans all of them.
+// The goroutines check the deadline before taking every batch, so they stop cooperatively within
+// a batch of the deadline and the search always waits for the started ones before returning.
+func chunkSearch(q *query, source []string, algo algorithm, o *options, deadline time.Time) Result {
+	workers := min(o.workers, len(source)/o.minChunk)
+
+	if workers <= 1 {
+		if deadline.IsZero() {
+			return Result{Matches: find(make([]Match, 0, len(source)), q, source, algo, o), Coverage: 1}
+		}
+		// the sequential search must check the deadline too, so it runs a single batched worker
+		workers = 1
 	}
 
-	var wg sync.WaitGroup
-	cs := len(source) / cpu
-	cc := (len(source) + cs - 1) / cs
-	rChan := make(chan []Match, cc)
+	start := time.Now()
+	var stats []Stats
+	if o.collect() {
+		stats = make([]Stats, workers)
+	}
 
-	wg.Add(cc)
-	for i := range cc {
-		go func(chunk []string) {
-			defer wg.Done()
-			mm := algo(query, chunk)
-			for j := range mm {
-				mm[j].Position += i * cs
+	var cursor, scanned atomic.Int64
+	batches := (len(source) + o.batch - 1) / o.batch
+	slots := make([][]Match, batches)
+	done := make(chan struct{}, workers)
+
+	worker := func(w int) {
+		// deferred first, so that it runs after the statistics are written
+		defer func() { done <- struct{}{} }()
+		sc := getScratch()
+		defer putScratch(sc)
+		if stats != nil {
+			sc.stats = &stats[w]
+			defer func(start time.Time) {
+				sc.stats.Chunks = []time.Duration{time.Since(start)}
+				sc.stats = nil
+			}(time.Now())
+		}
+		mm := make([]Match, 0, len(source)/workers)
+		for {
+			if !deadline.IsZero() && time.Now().After(deadline) {
+				break
+			}
+			b := int(cursor.Add(1)) - 1
+			if b >= batches {
+				break
 			}
-			rChan <- mm
-		}(source[i*cs : min(((i*cs)+cs), len(source))])
+			start, end, n := b*o.batch, min((b+1)*o.batch, len(source)), len(mm)
+			mm = scan(q, algo, sc, source[start:end], start, mm)
+			slots[b] = mm[n:len(mm):len(mm)]
+			scanned.Add(int64(end - start))
+		}
 	}
 
-	go func() {
-		wg.Wait()
-		close(rChan)
-	}()
+	started := 1
+	for w := 1; w < workers; w++ {
+		if o.pool.tryRun(func() { worker(w) }) {
+			started++
+		}
+	}
+	worker(0)
+
+	for range started {
+		<-done
+	}
 
 	r := make([]Match, 0, len(source))
-	for mm := range rChan {
+	for _, mm := range slots {
 		r = append(r, mm...)
 	}
 
-	return r
+	if stats != nil {
+		o.report(mergeStats(stats, time.Since(start)))
+	}
+
+	return newResult(r, int(scanned.Load()), len(source))
 }
 
 // SortMatches sorts the matches by score and position.
@@ -67,22 +129,17 @@ func chunkFind(query string, source []string, algo func(q string, s []string) []
 //   - if the scores are different, the score is used to determine the order
 //
 // Lower the score, better the match.
+// It's equivalent to Rank(m, nil, ByScore, ByPosition), use Rank for a different ordering.
 func SortMatches(m []Match) []Match {
-	slices.SortFunc(m, func(a, b Match) int {
-		if a.Score == b.Score {
-			return a.Position - b.Position
-		}
-		return a.Score - b.Score
-	})
-	return m
+	return Rank(m, nil, ByScore, ByPosition)
 }
 
 // Find searches for the value in the source and returns the matches.
 // The result is a slice of Match structs, which contain the score (lower is better)
 // and the position of the match in the source (e.g. source[Match.Position]).
 //
-// By default, the search is case insensitive, but it can be made case sensitive by capitalizing the query.
-// This behavior isn't affected by the filters.
+// By default, the search is case insensitive, but every part of the query (the text or a filter)
+// can be made case sensitive by capitalizing it, e.g. "foo *Bar" (see WithCase).
 //
 // It it possible to add filters to the query (value), each filter must be separated by a space:
 //   - if the filter starts with *, the source line must contain the filter
@@ -104,18 +161,41 @@ func SortMatches(m []Match) []Match {
 //
 // The result is unsorted.
 // If you want to sort the result, use the SortMatches function.
-func Find(queryValue string, source []string) []Match {
-	return find(queryValue, source, matchScore)
+//
+// The statistics of the search can be collected with the WithStats and WithStatsHook options
+// (the options about the parallelism are ignored, see ChunkFind).
+func Find(queryValue string, source []string, opts ...Option) []Match {
+	o := newOptions(opts)
+	q := newQuery(queryValue, &o)
+	return find(make([]Match, 0, len(source)), &q, source, standard, &o)
 }
 
 // LevenshteinFind acts the same as Find, but it uses the Levenshtein distance to calculate the score.
 // In this case the matches are more approximate, in fact to have a match the source line must contain at least 60% of the query.
 // This is useful when the query is misspelled or when the source contains typos.
-func LevenshteinFind(queryValue string, source []string) []Match {
-	c := make([]int, len(queryValue)+1, len(queryValue)+1)
-	return find(queryValue, source, func(q, s string, f func(string) (string, bool)) int {
-		return levenshteinScore(q, s, f, c)
-	})
+func LevenshteinFind(queryValue string, source []string, opts ...Option) []Match {
+	o := newOptions(opts)
+	q := newQuery(queryValue, &o)
+	return find(make([]Match, 0, len(source)), &q, source, levenshtein, &o)
+}
+
+// AppendFind acts the same as Find, but it appends the matches to dst and returns the extended slice.
+// Reusing the same dst across searches (e.g. AppendFind(dst[:0], query, source)) avoids allocating
+// a new result slice every time: once dst is big enough, a search without filters on ASCII lines
+// doesn't allocate at all. Queries with filters allocate only while being parsed (e.g. to compile
+// the regexes), never per line.
+func AppendFind(dst []Match, queryValue string, source []string, opts ...Option) []Match {
+	o := newOptions(opts)
+	q := newQuery(queryValue, &o)
+	return find(dst, &q, source, standard, &o)
+}
+
+// AppendLevenshteinFind acts the same as LevenshteinFind, but it appends the matches to dst
+// and returns the extended slice (see AppendFind).
+func AppendLevenshteinFind(dst []Match, queryValue string, source []string, opts ...Option) []Match {
+	o := newOptions(opts)
+	q := newQuery(queryValue, &o)
+	return find(dst, &q, source, levenshtein, &o)
 }
 
 // Match is a struct that contains the score and the position (in the source slice) of the match.
@@ -124,16 +204,117 @@ type Match struct {
 	Position int
 }
 
-// find searches for the query in the source and returns the matches.
-func find(q string, s []string, fn func(string, string, func(string) (string, bool)) int) []Match {
-	var f func(string) (string, bool)
-	q, f = input(q)
-	m := make([]Match, 0, len(s))
+// algorithm is the scoring algorithm of a search.
+type algorithm int
+
+const (
+	standard    algorithm = iota // the algorithm of Find
+	levenshtein                  // the algorithm of LevenshteinFind
+)
+
+// score calculates the score of the line for the query, using sc as working memory.
+// With FoldPinyin the text of the lines with Han characters is scored on the initials of their pinyin too,
+// and the best score wins. The filters are always matched on the pinyin.
+func (a algorithm) score(q *query, s string, sc *scratch) int {
+	score := a.scoreLine(q, s, sc)
+	if q.initials == nil || !hasHan(s) {
+		return score
+	}
+	if score < 0 && len(q.filters) > 0 {
+		if _, found := q.filter(s); !found {
+			return score
+		}
+	}
+
+	// the line is counted once in the statistics
+	stats := sc.stats
+	sc.stats = nil
+	initials := a.scoreLine(q.initials, s, sc)
+	sc.stats = stats
+	if initials < 0 || score >= 0 && score <= initials {
+		return score
+	}
+	if score < 0 && stats != nil {
+		stats.Matches++
+	}
+	return initials
+}
+
+// scoreLine calculates the score of the line for the query, without the initials of the pinyin (see score).
+func (a algorithm) scoreLine(q *query, s string, sc *scratch) int {
+	if q.terms != nil {
+		s = q.syntax.normalize(s, !q.keep)
+		if len(q.filters) > 0 {
+			var found bool
+			if s, found = q.apply(s); !found {
+				return sc.stats.filtered()
+			}
+		}
+		lower := s
+		if q.keep && !q.upper {
+			lower = q.syntax.normalize(s, true)
+		}
+		return sc.stats.scored(a.scoreTerms(q, s, lower, sc))
+	}
+
+	if q.ascii && isASCII(s) {
+		var found bool
+		if sc.buf, found = q.filterASCII(sc.buf[:0], s); !found {
+			return sc.stats.filtered()
+		}
+		score := a.scoreNormalizedASCII(q.text, sc.buf, sc)
+		if sc.stats != nil {
+			record(sc.stats, a, q.text, sc.buf, score)
+		}
+		return score
+	}
 
-	for i, l := range s {
-		score := fn(q, l, f)
+	s, found := q.filter(s)
+	if !found {
+		return sc.stats.filtered()
+	}
+	score := a.scoreNormalized(q.text, s, sc)
+	if sc.stats != nil {
+		record(sc.stats, a, q.text, s, score)
+	}
+	return score
+}
+
+// scoreNormalized calculates the score of a line already normalized by the filters of the query.
+func (a algorithm) scoreNormalized(q, s string, sc *scratch) int {
+	if a == levenshtein {
+		return levenshteinScore(q, s, sc.column(len(q)))
+	}
+	return matchScore(q, s)
+}
+
+// scoreNormalizedASCII is the version of scoreNormalized for ASCII lines.
+func (a algorithm) scoreNormalizedASCII(q string, s []byte, sc *scratch) int {
+	if a == levenshtein {
+		return levenshteinScoreASCII(q, s, sc.column(len(q)))
+	}
+	return matchScoreASCII(q, s)
+}
+
+// find searches for the query in the source and appends the matches to m.
+func find(m []Match, q *query, s []string, algo algorithm, o *options) []Match {
+	sc := getScratch()
+	defer putScratch(sc)
+
+	t := o.track(sc)
+	m = scan(q, algo, sc, s, 0, m)
+	t.stop(o, sc)
+
+	return m
+}
+
+// scan scores the lines and appends the matches to m.
+// The offset is added to the position of the matches (the position of lines[0] in the source).
+func scan(q *query, algo algorithm, sc *scratch, lines []string, offset int, m []Match) []Match {
+	for i, l := range lines {
+		score := algo.score(q, l, sc)
 		if score >= 0 {
-			m = append(m, Match{Score: score, Position: i})
+			m = append(m, Match{Score: score, Position: offset + i})
 		}
 	}
 
@@ -144,8 +325,10 @@ func find(q string, s []string, fn func(string, string, func(string) (string, bo
 // the standard matching algorithm. It returns the score value where lower is better.
 // A negative score indicates no match.
 // This function handles the query preprocessing and filter application internally.
-func MatchScore(queryValue, source string) int {
-	return score(queryValue, source, matchScore)
+// The options about the syntax of the query (e.g. WithOperators) apply, the others are ignored.
+func MatchScore(queryValue, source string, opts ...Option) int {
+	o := newOptions(opts)
+	return score(queryValue, source, standard, &o)
 }
 
 // LevenshteinScore calculates the match score between a query and a source string using
@@ -153,30 +336,29 @@ func MatchScore(queryValue, source string) int {
 // A negative score indicates no match.
 // This function is useful for approximate matching when queries or sources might contain typos.
 // This function handles the query preprocessing and filter application internally.
-func LevenshteinScore(queryValue, source string) int {
-	return score(queryValue, source, func(q, s string, f func(string) (string, bool)) int {
-		return levenshteinScore(q, s, f, make([]int, len(q)+1, len(q)+1))
-	})
+// The options about the syntax of the query (e.g. WithOperators) apply, the others are ignored.
+func LevenshteinScore(queryValue, source string, opts ...Option) int {
+	o := newOptions(opts)
+	return score(queryValue, source, levenshtein, &o)
 }
 
-// score is a helper function that processes the query using the input function and applies
-// the provided scoring function to calculate the match score between the query and source.
-// It's used internally by MatchScore and LevenshteinScore.
-func score(q string, s string, fn func(string, string, func(string) (string, bool)) int) int {
-	var f func(string) (string, bool)
-	q, f = input(q)
-	return fn(q, s, f)
+// score is a helper function that parses the query with the options and scores the source
+// with the given algorithm. It's used internally by MatchScore and LevenshteinScore.
+func score(q string, s string, algo algorithm, o *options) int {
+	p := newQuery(q, o)
+	sc := getScratch()
+	defer putScratch(sc)
+	return algo.score(&p, s, sc)
 }
 
 // matchScore calculates the score of the match.
-func matchScore(q, s string, f func(string) (string, bool)) int {
-	var found bool
-	s, found = f(s)
+// The line must be already normalized with query.filter.
+func matchScore(q, s string) int {
 	ql, sl := len(q), len(s)
 
 	// preliminary check to optimize algorithm speed
 	switch {
-	case !found || sl < ql:
+	case sl < ql:
 		return -1
 	case q == s, q == "":
 		return 0
@@ -203,14 +385,13 @@ Outer:
 }
 
 // levenshteinScore calculates the score of the match using the Levenshtein distance.
-func levenshteinScore(q, s string, f func(string) (string, bool), column []int) int {
-	var found bool
-	s, found = f(s)
+// The line must be already normalized with query.filter.
+func levenshteinScore(q, s string, column []int) int {
 	ql, sl := len(q), len(s)
 
 	// preliminary check to optimize algorithm speed
 	switch {
-	case !found || sl < ql:
+	case sl < ql:
 		return -1
 	case q == s, q == "":
 		return 0
@@ -237,6 +418,12 @@ Outer:
 		return -1
 	}
 
+	return levenshteinDistance(q, s, column)
+}
+
+// levenshteinDistance calculates the Levenshtein distance between the query and the line.
+func levenshteinDistance[T string | []byte](q string, s T, column []int) int {
+	ql, sl := len(q), len(s)
 	for i := 0; i <= ql; i++ {
 		column[i] = i
 	}
@@ -263,72 +450,219 @@ Outer:
 	return column[ql]
 }
 
-// input returns the query and the filter function.
-func input(q string) (string, func(string) (string, bool)) {
-	if q == "" {
-		return "", func(s string) (string, bool) {
-			return s, true
-		}
+// query is the parsed form of a query.
+type query struct {
+	text     string
+	filters  []filter
+	terms    [][]term // the groups of terms, for the syntaxes that match every term on its own (e.g. fzf)
+	upper    bool     // the text is case sensitive (for the queries of terms, every term is)
+	keep     bool     // the line keeps its case through the filters, since a part of the query is case sensitive
+	ascii    bool
+	mask     uint64
+	syntax   syntax
+	initials *query // the query without filters matched on the initials of the pinyin of the lines, with FoldPinyin
+}
+
+// newQuery parses the query with the syntax of the options and compiles its regexes.
+func newQuery(q string, o *options) query {
+	p := parseQuery(q, o)
+	p.compile()
+	return p
+}
+
+// compile compiles the regexes of the query.
+func (q *query) compile() {
+	compile(q.filters)
+	if q.initials != nil {
+		compile(q.initials.filters)
 	}
+}
 
-	f := make([]string, 0)
-	b := &strings.Builder{}
+// parseQuery parses the query with the syntax of the options, without compiling its regexes,
+// since they can be checked before (see Limits).
+func parseQuery(q string, o *options) query {
+	p := parseSyntax(q, o)
+	// the lines with Han characters are matched on the initials of their pinyin too (e.g. "bj" for "北京")
+	if o.syntax.folding&FoldPinyin != 0 {
+		io := *o
+		io.syntax.folding |= foldInitials
+		initials := parseSyntax(q, &io)
+		initials.filters = nil
+		p.initials = &initials
+	}
+	return p
+}
 
-	for w := range strings.SplitSeq(q, " ") {
-		if strings.HasPrefix(w, "*") || strings.HasPrefix(w, "$") || strings.HasPrefix(w, "^") || strings.HasPrefix(w, "!") || strings.HasPrefix(w, "?") {
-			f = append(f, w)
-		} else {
-			b.WriteString(w)
-		}
+// parseSyntax parses the query with the grammar of the syntax of the options.
+func parseSyntax(q string, o *options) query {
+	switch {
+	case o.syntax.fzf():
+		return newTermQuery(nil, parseFZF(q, &o.syntax), o)
+	case o.syntax.terms():
+		f, terms := parseTerms(q, &o.syntax)
+		return newTermQuery(f, terms, o)
 	}
+	text, f := parse(q, &o.syntax)
+	return makeQuery(text, f, o)
+}
 
-	upper := isUpper(b.String())
-	if len(f) == 0 {
-		return b.String(), func(s string) (string, bool) {
-			if !upper {
-				s = strings.ToLower(s)
-			}
+// makeQuery creates a query from its parsed text and filters.
+// The queries with groups never take the ASCII path: its filters work in place,
+// so they can't restore the line when a group isn't satisfied.
+func makeQuery(text string, f []filter, o *options) query {
+	text, upper := o.syntax.prepare(text)
+	keep := upper || sensitiveFilters(f)
+	fold(f, keep)
+	q := query{
+		text:    text,
+		filters: f,
+		upper:   upper,
+		keep:    keep,
+		ascii:   isASCII(text) && !grouped(f) && o.syntax.folding&FoldTurkish == 0,
+		syntax:  o.syntax,
+	}
+	// the masks of the lines aren't folded
+	if o.syntax.folding == 0 {
+		q.mask = runeMask(text)
+	}
+	return q
+}
 
-			return removeWhitespace(s), true
-		}
+// filter applies the filters of the query to the line.
+// It returns the normalized line (folded, lowercased if the text isn't case sensitive, without whitespace)
+// and whether the line satisfies the filters.
+func (q *query) filter(s string) (string, bool) {
+	s = q.syntax.normalize(s, !q.keep)
+
+	s, found := q.apply(s)
+	if found && q.keep && !q.upper {
+		s = q.syntax.normalize(s, true)
 	}
+	return s, found
+}
 
-	return b.String(), func(s string) (string, bool) {
-		if !upper {
-			s = strings.ToLower(s)
+// apply applies the filters of the query to a line already lowercased (if needed) and removes the whitespace.
+func (q *query) apply(s string) (string, bool) {
+	found := true
+	for _, fv := range q.filters {
+		if !found {
+			return "", false
 		}
+		s, found, _ = fv.apply(s)
+	}
 
-		found := true
-		reverse := false
-		for _, fv := range f {
-			if !found {
-				return "", false
-			}
+	return removeWhitespace(s), found
+}
 
-			fv, reverse = strings.CutPrefix(fv, "!")
-			switch {
-			case strings.HasPrefix(fv, "?"):
-				re, err := regexp.Compile(fv[1:])
-				if err != nil {
-					return "", false
-				}
-				found = re.MatchString(s)
-			case strings.HasPrefix(fv, "$"):
-				s, found = strings.CutSuffix(s, fv[1:])
-			case strings.HasPrefix(fv, "^"):
-				s, found = strings.CutPrefix(s, fv[1:])
-			default:
-				b, a, fo := strings.Cut(s, fv[1:])
-				s, found = b+a, fo
-			}
+// filter is a single filter of the query (e.g. "*foo", "!$bar" or `?\d+`),
+// or an expression of filters: an alternation ("$.go | $.mod") or a group ("!(^cmd $.go)").
+type filter struct {
+	raw       string
+	op        byte // the operator, '|' for the alternations and '(' for the groups
+	value     string
+	reverse   bool
+	re        *regexp.Regexp
+	terms     []filter // the alternatives of an alternation or the filters of a group
+	sensitive bool     // the filter is case sensitive (its value is lowercased if it isn't)
+	fold      bool     // the filter ignores the case of the line, which isn't lowercased (see fold)
+	folding   Folding  // the foldings of the query, to ignore the case of the line in the same way
+}
 
-			if reverse {
-				found = !found
+// apply applies the filter to the line.
+// It returns the line without the text consumed by the filter, whether the filter is satisfied
+// and the consumed text (if any).
+func (fv filter) apply(s string) (string, bool, string) {
+	return fv.applyAt(s, nil)
+}
+
+// applyAt is the version of apply that removes the offsets of the consumed text from at, if it isn't nil
+// (see Explain). The offsets are left untouched if the filter isn't satisfied.
+func (fv filter) applyAt(s string, at *[]int) (string, bool, string) {
+	var found bool
+	var removed string
+	i := -1 // the index of the consumed text
+	switch fv.op {
+	case '|':
+		// the first satisfied alternative is applied
+		for _, t := range fv.terms {
+			if r, ok, rm := t.applyAt(s, at); ok {
+				s, found, removed = r, true, rm
+				break
+			}
+		}
+	case '(':
+		// the filters are applied in order, the line is left untouched if one of them isn't satisfied
+		var saved []int
+		if at != nil {
+			saved = slices.Clone(*at)
+		}
+		r := s
+		found = true
+		for _, t := range fv.terms {
+			var rm string
+			if r, found, rm = t.applyAt(r, at); !found {
+				break
+			}
+			removed += rm
+		}
+		if found {
+			s = r
+		} else if at != nil {
+			*at = saved
+		}
+	case '?':
+		if fv.re == nil {
+			return "", false, ""
+		}
+		found = fv.re.MatchString(s)
+	case '$':
+		if fv.fold {
+			if n := suffixFold(s, fv.value, fv.folding); n >= 0 {
+				s, found, removed, i = s[:len(s)-n], true, s[len(s)-n:], len(s)-n
 			}
+			break
+		}
+		if s, found = strings.CutSuffix(s, fv.value); found {
+			removed, i = fv.value, len(s)
 		}
+	case '^':
+		if fv.fold {
+			if n := prefixFold(s, fv.value, fv.folding); n >= 0 {
+				s, found, removed, i = s[n:], true, s[:n], 0
+			}
+			break
+		}
+		if s, found = strings.CutPrefix(s, fv.value); found {
+			removed, i = fv.value, 0
+		}
+	default:
+		if fv.fold {
+			b, a, fo := cutFold(s, fv.value, fv.folding)
+			if fo {
+				removed, i = s[len(b):len(s)-len(a)], len(b)
+				s = b + a
+			}
+			found = fo
+			break
+		}
+		b, a, fo := strings.Cut(s, fv.value)
+		s, found = b+a, fo
+		if found {
+			removed, i = fv.value, len(b)
+		}
+	}
 
-		return removeWhitespace(s), found
+	if !found {
+		removed = ""
+	}
+	if fv.reverse {
+		// a negated filter never consumes text, not even when it isn't satisfied
+		found, removed = !found, ""
+	} else if at != nil && i >= 0 {
+		*at = slices.Delete(*at, i, i+len(removed))
 	}
+
+	return s, found, removed
 }
 
 // removeWhitespace removes the whitespace from the string.
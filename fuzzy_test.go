@@ -3,8 +3,11 @@ package fuzzy
 import (
 	"fmt"
 	"reflect"
+	"slices"
 	"sort"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestSortMatches(t *testing.T) {
@@ -56,7 +59,7 @@ func TestSortMatches(t *testing.T) {
 	}
 }
 
-func TestInput(t *testing.T) {
+func TestQueryFilter(t *testing.T) {
 	testCases := []struct {
 		name           string
 		query          string
@@ -181,15 +184,16 @@ func TestInput(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			query, filterFunc := input(tc.query)
+			o := newOptions(nil)
+			q := newQuery(tc.query, &o)
 
 			// Verify the query part
-			if query != tc.expectedQuery {
-				t.Errorf("Expected query '%s', got '%s'", tc.expectedQuery, query)
+			if q.text != tc.expectedQuery {
+				t.Errorf("Expected query '%s', got '%s'", tc.expectedQuery, q.text)
 			}
 
-			// Test the filter function
-			result, found := filterFunc(tc.line)
+			// Test the filters
+			result, found := q.filter(tc.line)
 			if found != tc.expectFound {
 				t.Errorf("Expected found=%v, got found=%v", tc.expectFound, found)
 			}
@@ -498,6 +502,115 @@ func TestLevenshteinFind(t *testing.T) {
 	}
 }
 
+func TestAppendFind(t *testing.T) {
+	source := []string{"test", "testing", "example", "tset"}
+	dst := []Match{{Score: 9, Position: 9}}
+
+	result := AppendFind(dst, "test", source)
+	expected := []Match{{Score: 9, Position: 9}, {Score: 0, Position: 0}, {Score: 3, Position: 1}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	result = AppendLevenshteinFind(result[:0], "tset", source)
+	expected = []Match{{Score: 2, Position: 0}, {Score: 5, Position: 1}, {Score: 0, Position: 3}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestAllocations(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector makes sync.Pool allocate")
+	}
+
+	small, large := make([]string, 10), make([]string, 1000)
+	for i := range large {
+		large[i] = fmt.Sprintf("Test line %d", i)
+	}
+	copy(small, large)
+	dst := make([]Match, 0, len(large))
+
+	testCases := []struct {
+		name  string
+		query string
+		max   float64
+	}{
+		{"Single word", "tst", 0},
+		{"Multiple words", "te st", 2},
+		{"Filters", "*line ?\\d+ test", 12},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			searches := []struct {
+				name string
+				fn   func([]string)
+			}{
+				{"AppendFind", func(s []string) { dst = AppendFind(dst[:0], tc.query, s) }},
+				{"AppendLevenshteinFind", func(s []string) { dst = AppendLevenshteinFind(dst[:0], tc.query, s) }},
+				{"MatchScore", func(s []string) { MatchScore(tc.query, s[0]) }},
+				{"LevenshteinScore", func(s []string) { LevenshteinScore(tc.query, s[0]) }},
+			}
+
+			for _, search := range searches {
+				// the allocations of the query parsing must not depend on the size of the source
+				allocsSmall := testing.AllocsPerRun(20, func() { search.fn(small) })
+				allocsLarge := testing.AllocsPerRun(20, func() { search.fn(large) })
+				if allocsLarge > tc.max || allocsLarge != allocsSmall {
+					t.Errorf("%s: expected at most %v allocations, got %v (%v with a smaller source)",
+						search.name, tc.max, allocsLarge, allocsSmall)
+				}
+			}
+		})
+	}
+}
+
+func TestChunkFind(t *testing.T) {
+	source := make([]string, 1000)
+	for i := range source {
+		source[i] = fmt.Sprintf("test%d", i)
+	}
+
+	pool := NewPool(3)
+	defer pool.Close()
+
+	testCases := []struct {
+		name string
+		opts []Option
+	}{
+		{name: "Default options"},
+		{name: "Custom workers", opts: []Option{WithWorkers(4), WithMinChunkSize(10)}},
+		{name: "Uneven chunks", opts: []Option{WithWorkers(7), WithMinChunkSize(1)}},
+		{name: "Min chunk size too big", opts: []Option{WithWorkers(4), WithMinChunkSize(1000)}},
+		{name: "Single line batches", opts: []Option{WithWorkers(4), WithMinChunkSize(10), WithBatchSize(1)}},
+		{name: "Batch bigger than the source", opts: []Option{WithWorkers(4), WithMinChunkSize(10), WithBatchSize(5000)}},
+		{name: "Pool", opts: []Option{WithPool(pool), WithMinChunkSize(10)}},
+		{name: "Pool with more workers", opts: []Option{WithPool(pool), WithWorkers(8), WithMinChunkSize(10)}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// the result must have the same order of the sequential search, run after run
+			for range 5 {
+				for _, q := range []string{"test1", "t9", "tset"} {
+					expected := Find(q, source)
+					result := ChunkFind(q, source, tc.opts...)
+					if !reflect.DeepEqual(result, expected) {
+						t.Errorf("ChunkFind(%q): expected %d matches, got %d (or a different order)", q, len(expected), len(result))
+					}
+
+					expected = LevenshteinFind(q, source)
+					result = ChunkLevenshteinFind(q, source, tc.opts...)
+					if !reflect.DeepEqual(result, expected) {
+						t.Errorf("ChunkLevenshteinFind(%q): expected %d matches, got %d (or a different order)", q, len(expected), len(result))
+					}
+				}
+			}
+		})
+	}
+}
+
 func BenchmarkChunkFind(b *testing.B) {
 	source := make([]string, 10000)
 	for i := range source {
@@ -520,6 +633,63 @@ func BenchmarkChunkLevenshteinFind(b *testing.B) {
 	}
 }
 
+func BenchmarkChunkFindPool(b *testing.B) {
+	source := make([]string, 10000)
+	for i := range source {
+		source[i] = fmt.Sprintf("test%d", i)
+	}
+
+	pool := NewPool(0)
+	defer pool.Close()
+
+	for b.Loop() {
+		ChunkFind("test", source, WithPool(pool))
+	}
+}
+
+// skewedSource returns a corpus where the first lines are much longer (and slower to score) than the rest,
+// like a source containing minified files or stack traces.
+func skewedSource() []string {
+	source := make([]string, 20000)
+	long := strings.Repeat("at github.com/user/project/pkg.function(0x1234) ", 40)
+	for i := range source {
+		if i < len(source)/8 {
+			source[i] = fmt.Sprintf("%s%d", long, i)
+		} else {
+			source[i] = fmt.Sprintf("test%d", i)
+		}
+	}
+	return source
+}
+
+func BenchmarkChunkFindSkewed(b *testing.B) {
+	source := skewedSource()
+	workers := 4
+
+	testCases := []struct {
+		name string
+		opts []Option
+	}{
+		// a single batch per worker reproduces a static split of the source in equal chunks
+		{"Static split", []Option{WithWorkers(workers), WithMinChunkSize(1), WithBatchSize(len(source) / workers)}},
+		{"Dynamic batches", []Option{WithWorkers(workers), WithMinChunkSize(1)}},
+	}
+
+	for _, tc := range testCases {
+		b.Run(tc.name, func(b *testing.B) {
+			durations := make([]time.Duration, 0, 1024)
+			for b.Loop() {
+				start := time.Now()
+				ChunkLevenshteinFind("pkgfunc", source, tc.opts...)
+				durations = append(durations, time.Since(start))
+			}
+
+			slices.Sort(durations)
+			b.ReportMetric(float64(durations[len(durations)*99/100].Nanoseconds()), "p99-ns/op")
+		})
+	}
+}
+
 func BenchmarkFind(b *testing.B) {
 	source := make([]string, 10000)
 	for i := range source {
@@ -531,6 +701,19 @@ func BenchmarkFind(b *testing.B) {
 	}
 }
 
+func BenchmarkAppendFind(b *testing.B) {
+	source := make([]string, 10000)
+	for i := range source {
+		source[i] = fmt.Sprintf("test%d", i)
+	}
+	dst := make([]Match, 0, len(source))
+
+	b.ReportAllocs()
+	for b.Loop() {
+		dst = AppendFind(dst[:0], "test", source)
+	}
+}
+
 func BenchmarkLevenshteinFind(b *testing.B) {
 	source := make([]string, 10000)
 	for i := range source {
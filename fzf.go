@@ -0,0 +1,131 @@
+package fuzzy
+
+import (
+	"strings"
+	"unicode"
+)
+
+// parseFZF parses a query with the extended-search syntax of fzf (see SyntaxFZF).
+// Every word is a term matched on its own and the line must satisfy all of them:
+//
+//	foo     fuzzy match
+//	'foo    exact match (the line contains foo)
+//	'foo'   exact match on word boundaries
+//	^foo    the line starts with foo
+//	foo$    the line ends with foo
+//	^foo$   the line is foo
+//	!foo    the line doesn't contain foo (!^foo and !foo$ work too)
+//	!'foo   the line doesn't fuzzy match foo
+//	a | b   the line matches a or b
+//
+// The words are separated by whitespace, and `\ ` is a space inside a word.
+// Every term is case sensitive only if it has an uppercase character (smart case, see WithCase).
+func parseFZF(q string, syn *syntax) [][]term {
+	var groups [][]term
+	var group []term
+	var afterBar, next bool
+	for _, w := range fzfWords(q) {
+		if len(group) > 0 && !afterBar && w == "|" {
+			next, afterBar = false, true
+			continue
+		}
+		afterBar = false
+
+		t, ok := newFZFTerm(w, syn)
+		if !ok {
+			continue
+		}
+		if next {
+			groups = append(groups, group)
+			group = nil
+		}
+		group = append(group, t)
+		next = true
+	}
+	if len(group) > 0 {
+		groups = append(groups, group)
+	}
+
+	return groups
+}
+
+// newFZFTerm parses a single word of a fzf query, reporting false if the term is empty (e.g. "^").
+func newFZFTerm(w string, syn *syntax) (term, bool) {
+	t := term{raw: w, kind: termFuzzy}
+	text := w
+
+	if len(text) > 1 && text[0] == '!' {
+		t.inverse, t.kind = true, termExact
+		text = text[1:]
+	}
+	if text != "$" && strings.HasSuffix(text, "$") {
+		t.kind = termSuffix
+		text = text[:len(text)-1]
+	}
+
+	switch {
+	case len(text) > 2 && text[0] == '\'' && text[len(text)-1] == '\'':
+		t.kind = termBoundary
+		text = text[1 : len(text)-1]
+	case strings.HasPrefix(text, "'"):
+		if t.inverse {
+			t.kind = termFuzzy
+		} else {
+			t.kind = termExact
+		}
+		text = text[1:]
+	case strings.HasPrefix(text, "^"):
+		if t.kind == termSuffix {
+			t.kind = termEqual
+		} else {
+			t.kind = termPrefix
+		}
+		text = text[1:]
+	}
+
+	if text == "" {
+		return t, false
+	}
+
+	text, t.upper = syn.prepare(text)
+	if t.kind == termFuzzy {
+		// the fuzzy terms are scored on the lines without whitespace
+		text = removeWhitespace(text)
+	}
+	t.value = text
+	return t, text != ""
+}
+
+// fzfWords splits a fzf query in words, keeping the escaped spaces (`\ `) inside the words.
+func fzfWords(q string) []string {
+	var words []string
+	var b strings.Builder
+	escaped := false
+	for _, r := range q {
+		switch {
+		case escaped:
+			if r != ' ' {
+				b.WriteByte('\\')
+			}
+			b.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case unicode.IsSpace(r):
+			if b.Len() > 0 {
+				words = append(words, b.String())
+				b.Reset()
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if escaped {
+		b.WriteByte('\\')
+	}
+	if b.Len() > 0 {
+		words = append(words, b.String())
+	}
+
+	return words
+}
@@ -0,0 +1,182 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFZF(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		expected [][]term
+	}{
+		{name: "Empty", query: "  ", expected: nil},
+		{name: "Fuzzy", query: "foo", expected: [][]term{{{raw: "foo", kind: termFuzzy, value: "foo"}}}},
+		{name: "Exact", query: "'foo", expected: [][]term{{{raw: "'foo", kind: termExact, value: "foo"}}}},
+		{name: "Boundary", query: "'foo'", expected: [][]term{{{raw: "'foo'", kind: termBoundary, value: "foo"}}}},
+		{name: "Prefix", query: "^foo", expected: [][]term{{{raw: "^foo", kind: termPrefix, value: "foo"}}}},
+		{name: "Suffix", query: "foo$", expected: [][]term{{{raw: "foo$", kind: termSuffix, value: "foo"}}}},
+		{name: "Equal", query: "^foo$", expected: [][]term{{{raw: "^foo$", kind: termEqual, value: "foo"}}}},
+		{name: "Inverse", query: "!foo", expected: [][]term{{{raw: "!foo", kind: termExact, value: "foo", inverse: true}}}},
+		{name: "Inverse fuzzy", query: "!'foo", expected: [][]term{{{raw: "!'foo", kind: termFuzzy, value: "foo", inverse: true}}}},
+		{name: "Inverse prefix", query: "!^foo", expected: [][]term{{{raw: "!^foo", kind: termPrefix, value: "foo", inverse: true}}}},
+		{name: "Smart case", query: "Foo bar", expected: [][]term{
+			{{raw: "Foo", kind: termFuzzy, value: "Foo", upper: true}},
+			{{raw: "bar", kind: termFuzzy, value: "bar"}},
+		}},
+		{name: "Alternation", query: "^a | b$ c", expected: [][]term{
+			{{raw: "^a", kind: termPrefix, value: "a"}, {raw: "b$", kind: termSuffix, value: "b"}},
+			{{raw: "c", kind: termFuzzy, value: "c"}},
+		}},
+		{name: "Leading bar is a term", query: "| a", expected: [][]term{
+			{{raw: "|", kind: termFuzzy, value: "|"}},
+			{{raw: "a", kind: termFuzzy, value: "a"}},
+		}},
+		{name: "Escaped space", query: `'foo\ bar`, expected: [][]term{{{raw: "'foo bar", kind: termExact, value: "foo bar"}}}},
+		{name: "Lone operators", query: "! $ ^ '", expected: [][]term{
+			{{raw: "!", kind: termFuzzy, value: "!"}},
+			{{raw: "$", kind: termFuzzy, value: "$"}},
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			o := newOptions(nil)
+			if terms := parseFZF(tc.query, &o.syntax); !reflect.DeepEqual(terms, tc.expected) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, terms)
+			}
+		})
+	}
+}
+
+func TestFindFZF(t *testing.T) {
+	source := []string{
+		"src/config.go",
+		"test/config_test.go",
+		"src/configure.sh",
+		"README.md",
+		"  src/main.go",
+		"docs/Config.md",
+		"src/con fig.go",
+	}
+
+	testCases := []struct {
+		name     string
+		query    string
+		expected []int
+	}{
+		{name: "Every term in any order", query: "go config", expected: []int{0, 1, 6}},
+		{name: "Exact", query: "'config", expected: []int{0, 1, 2, 5}},
+		{name: "Boundary", query: "'config'", expected: []int{0, 1, 5}},
+		{name: "Prefix ignores the leading whitespace", query: "^src", expected: []int{0, 2, 4, 6}},
+		{name: "Suffix", query: ".go$", expected: []int{0, 1, 4, 6}},
+		{name: "Equal", query: "^readme.md$", expected: []int{3}},
+		{name: "Inverse", query: ".go$ !test", expected: []int{0, 4, 6}},
+		{name: "Inverse fuzzy", query: "src !'cfg", expected: []int{4}},
+		{name: "Alternation", query: "^docs | ^test config", expected: []int{1, 5}},
+		{name: "Smart case per term", query: "Config md", expected: []int{5}},
+		{name: "Escaped space", query: `'con\ fig`, expected: []int{6}},
+		{name: "Empty", query: "", expected: []int{0, 1, 2, 3, 4, 5, 6}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := []Option{WithSyntax(SyntaxFZF)}
+			result := make([]int, 0)
+			for _, m := range Find(tc.query, source, opts...) {
+				result = append(result, m.Position)
+			}
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Expected lines %v, got %v", tc.expected, result)
+			}
+
+			// every entry point must use the same syntax
+			expected := Find(tc.query, source, opts...)
+			if m := NewCorpus(source).Find(tc.query, opts...); !reflect.DeepEqual(m, expected) {
+				t.Errorf("Corpus.Find: expected %v, got %v", expected, m)
+			}
+			if m := NewArena(source).Find(tc.query, opts...); !reflect.DeepEqual(m, expected) {
+				t.Errorf("Arena.Find: expected %v, got %v", expected, m)
+			}
+			if m := ChunkFind(tc.query, source, append(opts, WithWorkers(2), WithMinChunkSize(1), WithBatchSize(1))...); !reflect.DeepEqual(m, expected) {
+				t.Errorf("ChunkFind: expected %v, got %v", expected, m)
+			}
+			for _, m := range expected {
+				if score := MatchScore(tc.query, source[m.Position], opts...); score != m.Score {
+					t.Errorf("MatchScore: expected %d for line %d, got %d", m.Score, m.Position, score)
+				}
+				if e := Explain(tc.query, source[m.Position], opts...); e.Score != m.Score {
+					t.Errorf("Explain: expected %d for line %d, got %+v", m.Score, m.Position, e)
+				}
+			}
+		})
+	}
+}
+
+func TestFZFScores(t *testing.T) {
+	source := []string{"config.go", "src/app/config.go", "cfg.go"}
+	m := SortMatches(Find("cfg go$", source, WithSyntax(SyntaxFZF)))
+	if len(m) != 3 || m[0].Position != 2 || m[2].Position != 1 {
+		t.Errorf("Expected the closest matches first, got %v", m)
+	}
+
+	if l := LevenshteinFind("cnfig", source, WithSyntax(SyntaxFZF)); len(l) != 2 {
+		t.Errorf("Expected the fuzzy terms to use the Levenshtein distance, got %v", l)
+	}
+}
+
+func TestFZFSyntaxKeys(t *testing.T) {
+	source := []string{"foo", "food", "bar"}
+
+	t.Run("Literal", func(t *testing.T) {
+		m := Find("'foo", []string{"'foo", "foo"}, WithSyntax(SyntaxFZF), WithLiteral())
+		if len(m) != 1 || m[0].Position != 0 {
+			t.Errorf("Expected the literal query to ignore the fzf syntax, got %v", m)
+		}
+	})
+
+	t.Run("Cache", func(t *testing.T) {
+		c := NewCache(100)
+		c.Find(1, "!d", source, WithSyntax(SyntaxFZF))
+		m := c.Find(1, "!o", source, WithSyntax(SyntaxFZF))
+		if stats := c.Stats(); stats.Misses != 2 {
+			t.Errorf("Expected 2 misses for different terms, got %d", stats.Misses)
+		}
+		if len(m) != 1 || m[0].Position != 2 {
+			t.Errorf("Expected the match of the second query, got %v", m)
+		}
+	})
+
+	t.Run("Incremental", func(t *testing.T) {
+		in := NewIncremental(source)
+		in.Find("!foo", WithSyntax(SyntaxFZF))
+		m := in.Find("!food", WithSyntax(SyntaxFZF))
+		if expected := Find("!food", source, WithSyntax(SyntaxFZF)); !reflect.DeepEqual(m, expected) {
+			t.Errorf("Expected %v, got %v", expected, m)
+		}
+	})
+
+	t.Run("Explain", func(t *testing.T) {
+		e := Explain("^fo | ^ba !d", "food", WithSyntax(SyntaxFZF))
+		expected := []TermResult{
+			{Term: "^fo", Matched: true, Score: 2},
+			{Term: "^ba", Matched: false, Score: -1},
+			{Term: "!d", Matched: false, Score: -1},
+		}
+		if e.Path != PathFiltered || !reflect.DeepEqual(e.Terms, expected) {
+			t.Errorf("Expected the filtered path with the terms %+v, got %+v", expected, e)
+		}
+	})
+
+	t.Run("SafeFind", func(t *testing.T) {
+		_, err := SafeFind("a b c", source, Limits{MaxFilters: 2}, WithSyntax(SyntaxFZF))
+		if le, ok := err.(*LimitError); !ok || le.Limit != LimitFilters || le.Value != 3 {
+			t.Errorf("Expected the filters limit with 3 terms, got %v", err)
+		}
+		m, err := SafeFind("?o", source, Limits{NoRegex: true}, WithSyntax(SyntaxFZF))
+		if err != nil || len(m) != 0 {
+			t.Errorf("Expected the regex operator to be text, got %v %v", m, err)
+		}
+	})
+}
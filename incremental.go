@@ -0,0 +1,80 @@
+package fuzzy
+
+import (
+	"slices"
+	"strings"
+)
+
+// Incremental is a stateful search for pickers, where the query usually grows one character at a time.
+// With the standard algorithm, every line that matches a query also matches any prefix of that query
+// (e.g. the matches of "confi" are a subset of the matches of "conf"), so when the new query extends
+// the previous one only the previous matches are scored again, instead of the whole source.
+// When the query doesn't extend the previous one (e.g. a character is deleted, or the filters or the
+// case sensitivity change) the whole source is scanned again.
+//
+// The results are the same of Find. An Incremental isn't safe for concurrent use.
+type Incremental struct {
+	source  []string
+	prev    query
+	matches []Match
+	valid   bool
+}
+
+// NewIncremental creates an incremental search on the source.
+// The source must not be modified while the search is in use (call Reset if it changes).
+func NewIncremental(source []string) *Incremental {
+	return &Incremental{source: source}
+}
+
+// Find acts the same as Find on the source, reusing the matches of the previous query when possible.
+func (in *Incremental) Find(queryValue string, opts ...Option) []Match {
+	o := newOptions(opts)
+	q := newQuery(queryValue, &o)
+	sc := getScratch()
+	defer putScratch(sc)
+
+	t := o.track(sc)
+	defer t.stop(&o, sc)
+	if in.valid && in.prev.refinedBy(&q) {
+		m := in.matches[:0]
+		for _, p := range in.matches {
+			if score := standard.score(&q, in.source[p.Position], sc); score >= 0 {
+				m = append(m, Match{Score: score, Position: p.Position})
+			}
+		}
+		in.matches = m
+	} else {
+		in.matches = scan(&q, standard, sc, in.source, 0, make([]Match, 0, len(in.source)))
+	}
+
+	in.prev, in.valid = q, true
+	return slices.Clone(in.matches)
+}
+
+// Reset discards the previous matches, so that the next search scans the whole source.
+func (in *Incremental) Reset() {
+	in.matches, in.valid = nil, false
+}
+
+// refinedBy checks if every line matching the next query also matches the query:
+// the text of the next query must extend the text of the query, with the same filters, case sensitivity and syntax.
+// The queries of terms are never refined, since a longer term can match lines that the shorter one didn't
+// (e.g. "!foo" then "!food", or "a" then "a | b").
+func (q *query) refinedBy(next *query) bool {
+	if q.terms != nil || next.terms != nil {
+		return false
+	}
+	if q.upper != next.upper || q.syntax != next.syntax || !strings.HasPrefix(next.text, q.text) || len(q.filters) != len(next.filters) {
+		return false
+	}
+	// with FoldPinyin the text matched on the initials must be refined too
+	if q.initials != nil && !q.initials.refinedBy(next.initials) {
+		return false
+	}
+	for i := range q.filters {
+		if q.filters[i].raw != next.filters[i].raw {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIncremental(t *testing.T) {
+	source := []string{
+		"config.go", "config_test.go", "configuration.md", "Config.yaml", "cmd/main.go",
+		"internal/conf/loader.go", "vendor/config/config.go", "README.md",
+	}
+
+	testCases := []struct {
+		name    string
+		queries []string
+	}{
+		{"Growing query", []string{"c", "co", "con", "conf", "confi", "config"}},
+		{"Deleted characters", []string{"config", "confi", "conf", "co"}},
+		{"Changed query", []string{"conf", "main", "mai"}},
+		{"Filters", []string{"con", "con !*vendor", "conf !*vendor", "conf !*vendor $.go", "config !*vendor $.go"}},
+		{"Case sensitivity", []string{"con", "conF", "Conf", "Confi"}},
+		{"Empty query", []string{"", "c", "", "co"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			in := NewIncremental(source)
+			for _, q := range tc.queries {
+				expected := Find(q, source)
+				result := in.Find(q)
+				if !reflect.DeepEqual(result, expected) {
+					t.Errorf("Query %q: expected %v, got %v", q, expected, result)
+				}
+
+				// modifying the result must not affect the next searches
+				SortMatches(result)
+				for i := range result {
+					result[i].Score = -1
+				}
+			}
+		})
+	}
+}
+
+func TestIncrementalReset(t *testing.T) {
+	source := []string{"config.go", "main.go"}
+	in := NewIncremental(source)
+	in.Find("co")
+
+	// the source changes, the previous matches are no longer valid
+	source[1] = "config.yaml"
+	in.Reset()
+
+	expected := Find("conf", source)
+	if result := in.Find("conf"); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestRefinedBy(t *testing.T) {
+	testCases := []struct {
+		prev, next string
+		expected   bool
+	}{
+		{"conf", "confi", true},
+		{"conf", "conf", true},
+		{"", "c", true},
+		{"confi", "conf", false},
+		{"conf", "conF", false},
+		{"conf *go", "confi *go", true},
+		{"conf *go", "confi *g", false},
+		{"conf *go", "confi", false},
+		{"conf", "confi *go", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.prev+" -> "+tc.next, func(t *testing.T) {
+			prev, next := testQuery(tc.prev), testQuery(tc.next)
+			if result := prev.refinedBy(&next); result != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
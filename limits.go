@@ -0,0 +1,193 @@
+package fuzzy
+
+import (
+	"fmt"
+	resyntax "regexp/syntax"
+)
+
+// Limits are the limits of a safe search (see SafeFind), for the queries that come from untrusted users
+// (e.g. a public search endpoint). A zero value means no limit.
+type Limits struct {
+	NoRegex        bool // reject the regex filters
+	MaxQueryLength int  // the maximum length of the query, in bytes
+	MaxFilters     int  // the maximum number of filters, counting every filter inside the groups
+	MaxRegexSize   int  // the maximum size of every regex, in instructions of its compiled program
+	MaxWork        int  // the maximum work of the search, estimated before it runs (see SafeFind)
+}
+
+// Limit identifies one of the Limits.
+type Limit int
+
+const (
+	// LimitRegex means the query has a regex filter, but they aren't allowed.
+	LimitRegex Limit = iota
+	// LimitQueryLength means the query is too long.
+	LimitQueryLength
+	// LimitFilters means the query has too many filters.
+	LimitFilters
+	// LimitRegexSize means a regex of the query is too big.
+	LimitRegexSize
+	// LimitWork means the search would do too much work.
+	LimitWork
+)
+
+// String returns the name of the limit.
+func (l Limit) String() string {
+	switch l {
+	case LimitRegex:
+		return "regex"
+	case LimitQueryLength:
+		return "query length"
+	case LimitFilters:
+		return "filters"
+	case LimitRegexSize:
+		return "regex size"
+	case LimitWork:
+		return "work"
+	}
+	return "unknown"
+}
+
+// LimitError is the error of a query that exceeds the Limits of a safe search.
+type LimitError struct {
+	Limit  Limit  // the limit exceeded
+	Filter string // the filter that exceeded the limit, as written in the query (only for the regex limits)
+	Value  int    // the value that exceeded the limit (for the work, the part counted until the limit was exceeded)
+	Max    int    // the maximum value allowed
+}
+
+// Error returns the description of the error.
+func (e *LimitError) Error() string {
+	switch e.Limit {
+	case LimitRegex:
+		return fmt.Sprintf("fuzzy: regex filter %q not allowed", e.Filter)
+	case LimitRegexSize:
+		return fmt.Sprintf("fuzzy: regex filter %q has size %d, over the limit of %d", e.Filter, e.Value, e.Max)
+	}
+	return fmt.Sprintf("fuzzy: %s %d over the limit of %d", e.Limit, e.Value, e.Max)
+}
+
+// SafeFind acts the same as ChunkFind, but it checks the query against the limits before running the search,
+// returning a *LimitError if the query exceeds them. An invalid regex filter is an error too, instead of
+// a filter that is never satisfied. No regex is compiled before its size is checked.
+//
+// The work of the search is estimated as the size of the source in bytes, times the number of passes over
+// every line: one for every filter and one for the score (or, for SafeLevenshteinFind, the length of the query,
+// since the Levenshtein distance compares every byte of the line with every byte of the query).
+// It's an upper bound, since many lines are rejected or scored without scanning them completely.
+// With SyntaxFZF and SyntaxTerms every term counts as a filter.
+func SafeFind(queryValue string, source []string, limits Limits, opts ...Option) ([]Match, error) {
+	return safeFind(queryValue, source, standard, &limits, opts)
+}
+
+// SafeLevenshteinFind acts the same as ChunkLevenshteinFind, but it checks the query against the limits
+// before running the search (see SafeFind).
+func SafeLevenshteinFind(queryValue string, source []string, limits Limits, opts ...Option) ([]Match, error) {
+	return safeFind(queryValue, source, levenshtein, &limits, opts)
+}
+
+// safeFind checks the query against the limits and runs the search.
+func safeFind(queryValue string, source []string, algo algorithm, l *Limits, opts []Option) ([]Match, error) {
+	if l.MaxQueryLength > 0 && len(queryValue) > l.MaxQueryLength {
+		return nil, &LimitError{Limit: LimitQueryLength, Value: len(queryValue), Max: l.MaxQueryLength}
+	}
+
+	o := newOptions(opts)
+	q := parseQuery(queryValue, &o)
+
+	n, err := l.checkFilters(q.filters)
+	if err != nil {
+		return nil, err
+	}
+
+	passes := n + 1
+	if algo == levenshtein {
+		passes = n + max(1, len(q.text))
+	}
+	// every term counts as a filter, and as a pass over the line (or the length of the term,
+	// for a fuzzy term scored with the Levenshtein distance)
+	for _, group := range q.terms {
+		for _, t := range group {
+			n++
+			if algo == levenshtein && t.kind == termFuzzy {
+				passes += max(1, len(t.value))
+			} else {
+				passes++
+			}
+		}
+	}
+
+	// the lines with Han characters are scored twice with FoldPinyin (see FoldPinyin)
+	if q.initials != nil {
+		passes *= 2
+	}
+
+	if l.MaxFilters > 0 && n > l.MaxFilters {
+		return nil, &LimitError{Limit: LimitFilters, Value: n, Max: l.MaxFilters}
+	}
+	if l.MaxWork > 0 {
+		if work := estimateWork(source, passes, l.MaxWork); work > l.MaxWork {
+			return nil, &LimitError{Limit: LimitWork, Value: work, Max: l.MaxWork}
+		}
+	}
+
+	q.compile()
+	return chunkFind(&q, source, algo, &o), nil
+}
+
+// checkFilters checks the regexes of the filters and returns the number of filters.
+func (l *Limits) checkFilters(f []filter) (int, error) {
+	n := 0
+	for _, fv := range f {
+		if fv.terms != nil {
+			m, err := l.checkFilters(fv.terms)
+			if err != nil {
+				return 0, err
+			}
+			n += m
+			continue
+		}
+
+		n++
+		if fv.op != '?' {
+			continue
+		}
+		if l.NoRegex {
+			return 0, &LimitError{Limit: LimitRegex, Filter: fv.raw}
+		}
+		size, err := regexSize(fv.pattern())
+		if err != nil {
+			return 0, fmt.Errorf("fuzzy: invalid regex filter %q: %w", fv.raw, err)
+		}
+		if l.MaxRegexSize > 0 && size > l.MaxRegexSize {
+			return 0, &LimitError{Limit: LimitRegexSize, Filter: fv.raw, Value: size, Max: l.MaxRegexSize}
+		}
+	}
+	return n, nil
+}
+
+// regexSize returns the number of instructions of the compiled program of the regex,
+// parsing it the same way as regexp.Compile.
+func regexSize(expr string) (int, error) {
+	re, err := resyntax.Parse(expr, resyntax.Perl)
+	if err != nil {
+		return 0, err
+	}
+	prog, err := resyntax.Compile(re.Simplify())
+	if err != nil {
+		return 0, err
+	}
+	return len(prog.Inst), nil
+}
+
+// estimateWork returns the work of a search with the given passes over every line of the source.
+// It stops counting once the work is over the limit.
+func estimateWork(source []string, passes, limit int) int {
+	work := 0
+	for _, s := range source {
+		if work += len(s) * passes; work > limit {
+			break
+		}
+	}
+	return work
+}
@@ -0,0 +1,98 @@
+package fuzzy
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSafeFind(t *testing.T) {
+	source := []string{"main.go", "go.mod", "vendor/lib.go", "README.md", "issue 123", "issue 45"}
+
+	testCases := []struct {
+		name     string
+		query    string
+		limits   Limits
+		expected *LimitError
+	}{
+		{name: "No limits", query: "go ?^m !*vendor"},
+		{name: "Within the limits", query: "go $.go !*vendor", limits: Limits{NoRegex: true, MaxQueryLength: 20, MaxFilters: 2, MaxWork: 1000}},
+		{name: "Regex not allowed", query: "go ?^m", limits: Limits{NoRegex: true}, expected: &LimitError{Limit: LimitRegex, Filter: "?^m"}},
+		{name: "Regex in a group", query: "($.go | !?\\d)", limits: Limits{NoRegex: true}, expected: &LimitError{Limit: LimitRegex, Filter: "!?\\d"}},
+		{name: "Query too long", query: "go $.go !*vendor", limits: Limits{MaxQueryLength: 10}, expected: &LimitError{Limit: LimitQueryLength, Value: 16, Max: 10}},
+		{name: "Too many filters", query: "($.go | $.mod) !*vendor", limits: Limits{MaxFilters: 2}, expected: &LimitError{Limit: LimitFilters, Value: 3, Max: 2}},
+		{name: "Regex too big", query: "?a{1,100}", limits: Limits{MaxRegexSize: 50}, expected: &LimitError{Limit: LimitRegexSize, Filter: "?a{1,100}", Value: 201, Max: 50}},
+		// 2 passes (a filter and the score) over 7, 6 and 13 bytes, counted until the limit is exceeded
+		{name: "Too much work", query: "go $.go", limits: Limits{MaxWork: 40}, expected: &LimitError{Limit: LimitWork, Value: 52, Max: 40}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := SafeFind(tc.query, source, tc.limits)
+			if tc.expected == nil {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				if expected := ChunkFind(tc.query, source); !reflect.DeepEqual(result, expected) {
+					t.Errorf("Expected the matches of ChunkFind %v, got %v", expected, result)
+				}
+				return
+			}
+
+			var limitErr *LimitError
+			if !errors.As(err, &limitErr) {
+				t.Fatalf("Expected a *LimitError, got %v", err)
+			}
+			if !reflect.DeepEqual(limitErr, tc.expected) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, limitErr)
+			}
+			if result != nil {
+				t.Errorf("Expected no matches, got %v", result)
+			}
+		})
+	}
+}
+
+func TestSafeLevenshteinFind(t *testing.T) {
+	source := []string{strings.Repeat("x", 100)}
+
+	// the Levenshtein distance compares the line with every byte of the query
+	if _, err := SafeFind("abcd", source, Limits{MaxWork: 100}); err != nil {
+		t.Errorf("SafeFind: expected no error, got %v", err)
+	}
+	_, err := SafeLevenshteinFind("abcd", source, Limits{MaxWork: 100})
+	if limitErr, ok := err.(*LimitError); !ok || limitErr.Limit != LimitWork {
+		t.Errorf("SafeLevenshteinFind: expected a work limit error, got %v", err)
+	}
+}
+
+func TestSafeFindInvalidRegex(t *testing.T) {
+	_, err := SafeFind("?[", []string{"test"}, Limits{})
+	if err == nil || !strings.Contains(err.Error(), `"?["`) {
+		t.Errorf("Expected an error for the invalid regex, got %v", err)
+	}
+
+	var limitErr *LimitError
+	if errors.As(err, &limitErr) {
+		t.Errorf("Expected an invalid regex error, got %v", limitErr)
+	}
+}
+
+func TestLimitErrorMessage(t *testing.T) {
+	testCases := []struct {
+		err      *LimitError
+		expected string
+	}{
+		{&LimitError{Limit: LimitRegex, Filter: "?a"}, `fuzzy: regex filter "?a" not allowed`},
+		{&LimitError{Limit: LimitRegexSize, Filter: "?a+", Value: 10, Max: 5}, `fuzzy: regex filter "?a+" has size 10, over the limit of 5`},
+		{&LimitError{Limit: LimitQueryLength, Value: 300, Max: 256}, "fuzzy: query length 300 over the limit of 256"},
+		{&LimitError{Limit: LimitWork, Value: 2000, Max: 1000}, "fuzzy: work 2000 over the limit of 1000"},
+	}
+
+	for _, tc := range testCases {
+		if message := tc.err.Error(); message != tc.expected {
+			t.Errorf("Expected %q, got %q", tc.expected, message)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package fuzzy
+
+import "unicode"
+
+// runeMask returns a bitmask of the runes in the string, ignoring the case and the whitespace.
+// Every rune sets a single bit: letters and digits have their own bit, the other runes share the
+// remaining ones. If the mask of a line doesn't contain all the bits of the mask of a query,
+// the line can't contain all the runes of the query.
+func runeMask(s string) uint64 {
+	var m uint64
+	for _, r := range s {
+		if !unicode.IsSpace(r) {
+			m |= runeBit(r)
+		}
+	}
+	return m
+}
+
+// runeBit returns the bit of the rune in the masks, after lowering it as strings.ToLower does for the lines
+// (so "İ" and the Kelvin sign have the bits of "i" and "k").
+//   - bits 0-25 are the letters a-z (case insensitive)
+//   - bits 26-35 are the digits 0-9
+//   - bits 36-51 are shared by the other ASCII characters
+//   - bits 52-63 are shared by the non-ASCII runes
+func runeBit(r rune) uint64 {
+	if r > unicode.MaxASCII {
+		r = unicode.ToLower(r)
+	}
+	switch {
+	case 'a' <= r && r <= 'z':
+		return 1 << (r - 'a')
+	case 'A' <= r && r <= 'Z':
+		return 1 << (r - 'A')
+	case '0' <= r && r <= '9':
+		return 1 << (26 + r - '0')
+	case r <= unicode.MaxASCII:
+		return 1 << (36 + r%16)
+	}
+	return 1 << (52 + r%12)
+}
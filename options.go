@@ -0,0 +1,202 @@
+package fuzzy
+
+import (
+	"runtime"
+	"time"
+)
+
+// Option configures a search.
+type Option func(*options)
+
+// options holds the configuration of a search.
+type options struct {
+	workers  int
+	minChunk int
+	batch    int
+	pool     *Pool
+	stats    *Stats
+	hook     StatsHook
+	syntax   syntax
+}
+
+// newOptions returns the configuration of a search with the given options applied.
+// It doesn't allocate if there are no options.
+func newOptions(opts []Option) options {
+	o := options{
+		minChunk: 500,
+		batch:    64,
+		syntax:   syntax{ops: defaultOperators},
+	}
+	if len(opts) > 0 {
+		p := &options{}
+		*p = o
+		for _, opt := range opts {
+			opt(p)
+		}
+		o = *p
+	}
+
+	if o.workers <= 0 {
+		if o.pool != nil {
+			o.workers = o.pool.Workers()
+		} else {
+			o.workers = min(4, runtime.NumCPU()/2)
+		}
+	}
+	if o.minChunk <= 0 {
+		o.minChunk = 1
+	}
+	if o.batch <= 0 {
+		o.batch = 1
+	}
+
+	return o
+}
+
+// WithWorkers sets the number of goroutines used by the chunked searches.
+// By default it's half of the available CPUs (up to 4), or the size of the pool if WithPool is used.
+func WithWorkers(n int) Option {
+	return func(o *options) {
+		o.workers = n
+	}
+}
+
+// WithMinChunkSize sets the minimum number of lines processed by each goroutine of the chunked searches.
+// If the source is too small to give every worker at least n lines, fewer workers are used
+// (and the search isn't parallelized at all if only one worker is left). The default is 500.
+func WithMinChunkSize(n int) Option {
+	return func(o *options) {
+		o.minChunk = n
+	}
+}
+
+// WithBatchSize sets the number of lines that a goroutine of the chunked searches takes at a time.
+// Goroutines take a new batch as soon as they finish the previous one, so small batches balance
+// the work better when some lines are much more expensive than others, while big batches reduce
+// the synchronization overhead. The default is 64.
+func WithBatchSize(n int) Option {
+	return func(o *options) {
+		o.batch = n
+	}
+}
+
+// WithPool makes the chunked searches run on the goroutines of the pool instead of spawning new ones.
+// The calling goroutine scans the source too, and a busy pool doesn't block the search: it just runs with fewer workers.
+func WithPool(p *Pool) Option {
+	return func(o *options) {
+		o.pool = p
+	}
+}
+
+// WithStats writes the statistics of the search to s once the search is completed.
+// Every search reports them: a Corpus or an Arena counts the lines as Find does, an Incremental
+// counts only the lines it scores again and a hit of a Cache reports its matches without lines.
+func WithStats(s *Stats) Option {
+	return func(o *options) {
+		o.stats = s
+	}
+}
+
+// WithStatsHook sends the statistics of the search to the hook once the search is completed,
+// e.g. to forward them to a metrics system. The statistics are the ones of WithStats.
+func WithStatsHook(h StatsHook) Option {
+	return func(o *options) {
+		o.hook = h
+	}
+}
+
+// WithOperators sets the characters that start the filters of the query, e.g. to search for prices
+// like "$12" without them being taken as suffix filters:
+//
+//	ops := fuzzy.DefaultOperators()
+//	ops.Suffix = '%'
+//	fuzzy.Find("$12", source, fuzzy.WithOperators(ops))
+//
+// A zero character disables the operator. The characters of the groups ("(", ")" and "|") can't be changed.
+func WithOperators(ops Operators) Option {
+	return func(o *options) {
+		o.syntax.ops = ops
+	}
+}
+
+// WithSyntax sets the grammar of the query, e.g. to search with the fzf extended-search syntax:
+//
+//	fuzzy.Find("^src 'config .go$ !test", source, fuzzy.WithSyntax(fuzzy.SyntaxFZF))
+//
+// The operators set with WithOperators apply only to the default syntax, while WithLiteral applies to both.
+func WithSyntax(s Syntax) Option {
+	return func(o *options) {
+		o.syntax.grammar = s
+	}
+}
+
+// WithCase sets the case sensitivity of the query: by default every term is case sensitive only if it has
+// an uppercase character (CaseSmart), CaseIgnore and CaseRespect force the same sensitivity for every term.
+func WithCase(c Case) Option {
+	return func(o *options) {
+		o.syntax.casing = c
+	}
+}
+
+// WithFolding sets the foldings applied to the query and to the lines, e.g. to match "città" with "citta"
+// and "Straße" with "strasse":
+//
+//	fuzzy.Find("citta strasse", source, fuzzy.WithFolding(fuzzy.FoldAll))
+//
+// The lines are folded before the filters, so the filters match the folded lines too.
+// The foldings make the searches slower on the lines that aren't ASCII.
+func WithFolding(f Folding) Option {
+	return func(o *options) {
+		o.syntax.folding = f
+	}
+}
+
+// WithLiteral makes the whole query the text to search: there are no filters, groups, quotes or escapes.
+func WithLiteral() Option {
+	return func(o *options) {
+		o.syntax.literal = true
+	}
+}
+
+// collect checks if the statistics of the search must be collected.
+func (o *options) collect() bool {
+	return o.stats != nil || o.hook != nil
+}
+
+// tracker collects the statistics of a search run by a single goroutine (see options.track).
+type tracker struct {
+	start time.Time
+	stats []Stats // nil if the statistics aren't collected
+}
+
+// track starts collecting the statistics of a search run by a single goroutine in the scratch,
+// if they must be collected.
+func (o *options) track(sc *scratch) tracker {
+	if !o.collect() {
+		return tracker{}
+	}
+
+	t := tracker{start: time.Now(), stats: make([]Stats, 1)}
+	sc.stats = &t.stats[0]
+	return t
+}
+
+// stop stops collecting the statistics in the scratch and reports them.
+func (t tracker) stop(o *options, sc *scratch) {
+	if t.stats == nil {
+		return
+	}
+	sc.stats.Chunks = []time.Duration{time.Since(t.start)}
+	sc.stats = nil
+	o.report(mergeStats(t.stats, time.Since(t.start)))
+}
+
+// report delivers the statistics of a completed search.
+func (o *options) report(s Stats) {
+	if o.stats != nil {
+		*o.stats = s
+	}
+	if o.hook != nil {
+		o.hook.Record(s)
+	}
+}
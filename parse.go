@@ -0,0 +1,433 @@
+package fuzzy
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The words of a query are separated by whitespace (spaces, tabs, etc.): the words starting with an operator
+// (* $ ^ ? ! by default, see WithOperators) are filters, the others are joined into the text to search
+// (with WithLiteral the whole query is text). The filters can be combined:
+//
+//   - the words are joined by an implicit AND, e.g. "$.go !*vendor"
+//   - "|" (a word on its own) is an OR between the filters around it, e.g. "$.go | $.mod".
+//     It binds tighter than the implicit AND, so "$.go | $.mod !*vendor" means "($.go | $.mod) !*vendor"
+//   - the parentheses group the filters, e.g. "(^cmd $.go) | *internal", and "!(" negates a group
+//   - the plain words inside a group or an alternation are contains filters, e.g. "(foo | bar)" means "(*foo | *bar)"
+//
+// The alternatives are tried in order and only the first one satisfied removes its text from the line,
+// as the filters do. A group removes the text of its filters only if all of them are satisfied.
+//
+// The grammar of a query is:
+//
+//	query  = { expr }
+//	expr   = term { "|" term }
+//	term   = [ "!" ] "(" { expr } [ ")" ] | word
+//	word   = { char | phrase | escape }
+//	phrase = '"' { char | space | escape } '"'
+//	escape = "\" char
+//
+// The tokens are separated by whitespace, except that "(" and "!(" can be followed by a word
+// and ")" can follow a word. Inside a word:
+//
+//   - a phrase between double quotes can contain whitespace, e.g. `*"hello world"`, and its characters
+//     have no special meaning, e.g. `"$HOME"` searches for the text "$HOME"
+//   - a backslash escapes the next character, e.g. `\$HOME` searches for the text "$HOME", `*a\ b` contains "a b",
+//     `\(foo` and `\|` are text and `\"` is a double quote. In the regex filters (? and !?) the backslashes are left to the regex,
+//     e.g. `?\d+`, and they only prevent the next character from closing a phrase or a group
+//   - a ")" closes a group only at the end of the word and if it isn't balanced by a "(" of the same word,
+//     so the parentheses of a regex are part of the filter, e.g. "(?(a|b)c | $d)"
+//
+// The text is matched without its whitespace, as the lines are, so `"foo bar"` is the same of "foobar".
+// A group left open is closed at the end of the query, and a ")" that doesn't close a group is part of the word.
+// A group with nothing inside is a word of the text, e.g. "(" and "()" search for "(" and "()",
+// and so is a '"' that isn't closed by another one, e.g. `"abc` searches for `"abc`.
+
+// token is the kind of a token of a query.
+type token int
+
+const (
+	tokenEnd     token = iota // the end of the query
+	tokenFilter               // a filter
+	tokenText                 // a word of the text
+	tokenOr                   // "|"
+	tokenOpen                 // "("
+	tokenNotOpen              // "!("
+	tokenClose                // ")"
+)
+
+// lexer splits a query into tokens.
+// It allocates only to remove the quotes and the escapes from a word.
+type lexer struct {
+	syntax syntax
+	rest   string // the part of the query not read yet
+	closes int    // the ")" at the end of the last word not returned yet
+	depth  int    // the number of open groups
+}
+
+// next returns the next token of the query, both as written in the query and with the quotes
+// and the escapes removed.
+func (l *lexer) next() (token, string, string) {
+	if l.closes > 0 {
+		l.closes--
+		l.depth--
+		return tokenClose, ")", ")"
+	}
+
+	l.rest = strings.TrimLeftFunc(l.rest, unicode.IsSpace)
+	if l.rest == "" {
+		return tokenEnd, "", ""
+	}
+	if strings.HasPrefix(l.rest, "(") {
+		l.rest = l.rest[1:]
+		l.depth++
+		return tokenOpen, "(", "("
+	}
+	if rest, ok := l.syntax.cutNot(l.rest); ok && strings.HasPrefix(rest, "(") {
+		raw := l.rest[:len(l.rest)-len(rest)+1]
+		l.rest = rest[1:]
+		l.depth++
+		return tokenNotOpen, raw, raw
+	}
+
+	raw, escaped := l.word()
+	switch {
+	case raw == "":
+		// the word was made only of ")"
+		return l.next()
+	case raw == "|":
+		return tokenOr, raw, raw
+	}
+
+	tok := tokenText
+	if l.syntax.isFilter(raw) {
+		tok = tokenFilter
+	}
+	// the escapes of the regexes are left to the regex, so only their quotes must be removed
+	regex := tok == tokenFilter && l.syntax.isRegex(raw)
+	if !escaped || (regex && !strings.Contains(raw, `"`)) {
+		return tok, raw, raw
+	}
+	return tok, raw, unquote(raw, regex)
+}
+
+// word reads the next word as written in the query, leaving out the unbalanced ")" at its end
+// that close the open groups. It also reports if the word contains quotes or escapes.
+func (l *lexer) word() (string, bool) {
+	var quoted, escaped bool
+	opens, closes, trailing := 0, 0, 0
+
+	i := 0
+	for i < len(l.rest) {
+		r, size := utf8.DecodeRuneInString(l.rest[i:])
+		if !quoted && unicode.IsSpace(r) {
+			break
+		}
+
+		switch {
+		case r == '\\':
+			escaped = true
+			if i+size < len(l.rest) {
+				_, n := utf8.DecodeRuneInString(l.rest[i+size:])
+				size += n
+			}
+		case r == '"' && (quoted || closesQuote(l.rest[i+size:])):
+			escaped, quoted = true, !quoted
+		case !quoted && r == '(':
+			opens++
+		case !quoted && r == ')':
+			closes++
+		}
+
+		if !quoted && r == ')' {
+			trailing++
+		} else {
+			trailing = 0
+		}
+		i += size
+	}
+
+	w := l.rest[:i]
+	l.rest = l.rest[i:]
+	l.closes = max(0, min(trailing, closes-opens, l.depth))
+
+	return w[:len(w)-l.closes], escaped
+}
+
+// closesQuote checks if the rest of the query has a '"' (not escaped) that closes the phrase just opened.
+// A '"' without one is a character of the word.
+func closesQuote(rest string) bool {
+	for i := 0; i < len(rest); i++ {
+		switch rest[i] {
+		case '\\':
+			i++
+		case '"':
+			return true
+		}
+	}
+	return false
+}
+
+// unquote removes the quotes and the escapes from a word.
+// The escapes of the regexes are kept, since they are part of the regex.
+func unquote(w string, regex bool) string {
+	b := strings.Builder{}
+	b.Grow(len(w))
+	quoted := false
+	for i := 0; i < len(w); i++ {
+		switch c := w[i]; {
+		case c == '"' && (quoted || closesQuote(w[i+1:])):
+			quoted = !quoted
+		case c == '\\' && i+1 < len(w):
+			if regex {
+				b.WriteByte(c)
+			}
+			i++
+			b.WriteByte(w[i])
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// parser parses the filter expressions of a query.
+type parser struct {
+	lexer
+	tok  token
+	raw  string
+	text string
+}
+
+// advance reads the next token.
+func (p *parser) advance() {
+	p.tok, p.raw, p.text = p.next()
+}
+
+// parse splits the query into the text to search and the filters.
+// A query made of a single word doesn't allocate.
+func parse(q string, syn *syntax) (string, []filter) {
+	f := make([]filter, 0)
+	if syn.literal {
+		return removeWhitespace(q), f
+	}
+
+	var text string
+	var b *strings.Builder
+
+	p := parser{lexer: lexer{syntax: *syn, rest: q}}
+	p.advance()
+	for p.tok != tokenEnd {
+		fv, w, isText := p.alternation()
+		if isText {
+			// the lines are scored without whitespace, so a phrase is too
+			w = removeWhitespace(w)
+		}
+		switch {
+		case !isText:
+			f = appendFilter(f, fv)
+		case text == "":
+			text = w
+		case b == nil:
+			b = &strings.Builder{}
+			b.WriteString(text)
+			b.WriteString(w)
+		default:
+			b.WriteString(w)
+		}
+	}
+
+	if b != nil {
+		text = b.String()
+	}
+
+	return text, f
+}
+
+// parseTerms splits the query into the filters and the terms, for SyntaxTerms: every word of the text
+// is a fuzzy term on its own, optional if it starts with the optional operator (e.g. "~foo").
+// The case sensitivity of every term is decided on its own.
+func parseTerms(q string, syn *syntax) ([]filter, [][]term) {
+	f := make([]filter, 0)
+	var terms [][]term
+
+	p := parser{lexer: lexer{syntax: *syn, rest: q}}
+	p.advance()
+	for p.tok != tokenEnd {
+		raw := p.raw
+		fv, w, isText := p.alternation()
+		if !isText {
+			f = appendFilter(f, fv)
+			continue
+		}
+
+		t := term{raw: raw, kind: termFuzzy}
+		if _, t.optional = syn.cutOptional(raw); t.optional {
+			w, _ = syn.cutOptional(w)
+		}
+		// the lines are scored without whitespace, so a phrase is too
+		if t.value = removeWhitespace(w); t.value == "" {
+			continue
+		}
+		t.value, t.upper = syn.prepare(t.value)
+		terms = append(terms, []term{t})
+	}
+
+	return f, terms
+}
+
+// alternation parses terms separated by "|".
+// If there is a single term and it's a word of the text, it returns the word and true.
+func (p *parser) alternation() (filter, string, bool) {
+	fv, w, isText := p.term()
+	if p.tok != tokenOr {
+		return fv, w, isText
+	}
+
+	terms := []filter{asFilter(fv, w, isText)}
+	for p.tok == tokenOr {
+		p.advance()
+		if p.tok == tokenEnd || p.tok == tokenClose || p.tok == tokenOr {
+			continue
+		}
+		terms = append(terms, asFilter(p.term()))
+	}
+
+	if len(terms) == 1 {
+		// a "|" with nothing after it is ignored, e.g. "a |" is the same of "a"
+		return fv, w, isText
+	}
+	return filter{raw: joinRaw(terms, " | "), op: '|', terms: terms}, "", false
+}
+
+// term parses a filter, a group or a word of the text.
+// If it's a word of the text, it returns the word and true.
+func (p *parser) term() (filter, string, bool) {
+	switch p.tok {
+	case tokenOpen, tokenNotOpen:
+		reverse, not := p.tok == tokenNotOpen, strings.TrimSuffix(p.raw, "(")
+		p.advance()
+
+		terms := make([]filter, 0)
+		for p.tok != tokenClose && p.tok != tokenEnd {
+			terms = appendFilter(terms, asFilter(p.alternation()))
+		}
+		if len(terms) == 0 {
+			// an empty group is a word of the text, e.g. "(" searches for "("
+			w := not + "("
+			if p.tok == tokenClose {
+				w += ")"
+			}
+			p.advance()
+			return filter{}, w, true
+		}
+		// the groups left open are closed at the end of the query
+		p.advance()
+
+		if len(terms) == 1 && !reverse {
+			return terms[0], "", false
+		}
+		fv := filter{raw: "(" + joinRaw(terms, " ") + ")", op: '(', reverse: reverse, terms: terms}
+		if reverse {
+			fv.raw = not + fv.raw
+		}
+		return fv, "", false
+	case tokenOr:
+		// a "|" without a filter before it is a word of the text
+		p.advance()
+		return filter{}, "|", true
+	}
+
+	raw, w, tok := p.raw, p.text, p.tok
+	p.advance()
+	if tok == tokenFilter {
+		return newFilter(raw, w, &p.syntax), "", false
+	}
+	return filter{}, w, true
+}
+
+// asFilter turns a word of the text into a contains filter.
+func asFilter(fv filter, w string, isText bool) filter {
+	if isText {
+		return filter{raw: "*" + w, op: '*', value: w}
+	}
+	return fv
+}
+
+// appendFilter appends the filter to f, replacing a group that isn't negated with its filters
+// (e.g. "(^a $b)" is the same of "^a $b").
+func appendFilter(f []filter, fv filter) []filter {
+	if fv.op == '(' && !fv.reverse {
+		return append(f, fv.terms...)
+	}
+	return append(f, fv)
+}
+
+// joinRaw joins the filters as written in the query.
+func joinRaw(f []filter, sep string) string {
+	raw := make([]string, len(f))
+	for i, fv := range f {
+		raw[i] = fv.raw
+	}
+	return strings.Join(raw, sep)
+}
+
+// pattern returns the regex of a regex filter, made case insensitive if the filter ignores the case.
+func (fv *filter) pattern() string {
+	if fv.fold {
+		return "(?i)" + fv.value
+	}
+	return fv.value
+}
+
+// grouped checks if any of the filters is an alternation or a group.
+func grouped(f []filter) bool {
+	for _, fv := range f {
+		if fv.terms != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// newFilter parses a single filter, given as written in the query and without quotes and escapes.
+// The operators of the syntax are replaced with the default ones.
+// The regexes are compiled later by compile, so that they can be checked before (see Limits).
+func newFilter(raw, w string, syn *syntax) filter {
+	fv := filter{raw: raw}
+	w, fv.reverse = syn.cutNot(w)
+	if w == "" {
+		return fv
+	}
+
+	r, size := utf8.DecodeRuneInString(w)
+	fv.op, fv.value, fv.folding = syn.operator(r), w, syn.folding
+	if fv.op != 0 {
+		// a word without an operator (e.g. a negated one) is searched whole
+		fv.value = w[size:]
+	}
+	if fv.op == '?' {
+		// the regexes are folded, but they ignore the case with (?i)
+		fv.sensitive = syn.regexSensitive(fv.value)
+		fv.value = syn.normalize(fv.value, false)
+	} else {
+		fv.value, fv.sensitive = syn.prepare(fv.value)
+	}
+
+	return fv
+}
+
+// compile compiles the regexes of the filters, once for every query.
+// An invalid regex is left nil, so its filter is never satisfied.
+func compile(f []filter) {
+	for i := range f {
+		switch {
+		case f[i].op == '?':
+			f[i].re, _ = regexp.Compile(f[i].pattern())
+		case f[i].terms != nil:
+			compile(f[i].terms)
+		}
+	}
+}
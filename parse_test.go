@@ -0,0 +1,182 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name          string
+		query         string
+		expectedQuery string
+		expectedRaw   []string
+	}{
+		{name: "Empty query", query: "", expectedRaw: []string{}},
+		{name: "Text and filters", query: "foo *bar $baz", expectedQuery: "foo", expectedRaw: []string{"*bar", "$baz"}},
+		{name: "Alternation", query: "$.go | $.mod", expectedRaw: []string{"$.go | $.mod"}},
+		{name: "Alternation before AND", query: "$.go | $.mod !*vendor", expectedRaw: []string{"$.go | $.mod", "!*vendor"}},
+		{name: "Group", query: "($.go | $.mod) !*vendor", expectedRaw: []string{"$.go | $.mod", "!*vendor"}},
+		{name: "Group in alternation", query: "(^cmd $.go) | *internal", expectedRaw: []string{"(^cmd $.go) | *internal"}},
+		{name: "Group without alternation", query: "main (^cmd $.go)", expectedQuery: "main", expectedRaw: []string{"^cmd", "$.go"}},
+		{name: "Negated group", query: "!(^cmd $.go)", expectedRaw: []string{"!(^cmd $.go)"}},
+		{name: "Nested groups", query: "((^a | ^b) $c) | *d", expectedRaw: []string{"(^a | ^b $c) | *d"}},
+		{name: "Words in alternation", query: "foo | bar", expectedRaw: []string{"*foo | *bar"}},
+		{name: "Words in group", query: "main (foo bar)", expectedQuery: "main", expectedRaw: []string{"*foo", "*bar"}},
+		{name: "Regex with parentheses", query: "(?(a|b)c | $d)", expectedRaw: []string{"?(a|b)c | $d"}},
+		{name: "Unclosed group", query: "(*a | *b", expectedRaw: []string{"*a | *b"}},
+		{name: "Unopened parenthesis", query: "foo) *b)", expectedQuery: "foo)", expectedRaw: []string{"*b)"}},
+		{name: "Escaped parenthesis", query: "\\(foo", expectedQuery: "(foo", expectedRaw: []string{}},
+		{name: "Escaped bar", query: "a \\| b", expectedQuery: "a|b", expectedRaw: []string{}},
+		{name: "Leading bar", query: "| foo", expectedQuery: "|foo", expectedRaw: []string{}},
+		{name: "Trailing bar", query: "*a |", expectedRaw: []string{"*a"}},
+		{name: "Quoted parenthesis", query: `(*"a)" | $b)`, expectedRaw: []string{`*"a)" | $b`}},
+		{name: "Escaped closing parenthesis", query: `(*a\) | $b)`, expectedRaw: []string{`*a\) | $b`}},
+		{name: "Tabs around bar", query: "$a\t|\t$b", expectedRaw: []string{"$a | $b"}},
+		{name: "Lone parenthesis", query: "(", expectedQuery: "(", expectedRaw: []string{}},
+		{name: "Empty group", query: "()", expectedQuery: "()", expectedRaw: []string{}},
+		{name: "Empty negated group", query: "!() foo", expectedQuery: "!()foo", expectedRaw: []string{}},
+		{name: "Empty group in alternation", query: "$a | ()", expectedRaw: []string{"$a | *()"}},
+		{name: "Trailing bar after a word", query: "a |", expectedQuery: "a", expectedRaw: []string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			o := newOptions(nil)
+			query, f := parse(tc.query, &o.syntax)
+			if query != tc.expectedQuery {
+				t.Errorf("Expected query %q, got %q", tc.expectedQuery, query)
+			}
+
+			raw := make([]string, len(f))
+			for i, fv := range f {
+				raw[i] = fv.raw
+			}
+			if !reflect.DeepEqual(raw, tc.expectedRaw) {
+				t.Errorf("Expected filters %q, got %q", tc.expectedRaw, raw)
+			}
+		})
+	}
+}
+
+func TestParseWords(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		expectedQuery  string
+		expectedValues []string
+	}{
+		{name: "Tabs", query: "foo\tbar", expectedQuery: "foobar", expectedValues: []string{}},
+		{name: "Repeated spaces", query: "  foo   *bar  ", expectedQuery: "foo", expectedValues: []string{"bar"}},
+		{name: "Quoted filter", query: `*"hello world"`, expectedValues: []string{"hello world"}},
+		{name: "Quoted text", query: `"foo bar" baz`, expectedQuery: "foobarbaz", expectedValues: []string{}},
+		{name: "Quoted operator", query: `"$HOME"`, expectedQuery: "$HOME", expectedValues: []string{}},
+		{name: "Escaped operator", query: `\$HOME`, expectedQuery: "$HOME", expectedValues: []string{}},
+		{name: "Escaped space", query: `*a\ b`, expectedValues: []string{"a b"}},
+		{name: "Escaped quote", query: `$\"`, expectedValues: []string{`"`}},
+		{name: "Escaped operator in filter", query: `^\$x`, expectedValues: []string{"$x"}},
+		{name: "Quoted regex", query: `?"\d+ \w+"`, expectedValues: []string{`\d+ \w+`}},
+		{name: "Regex escapes", query: `?\(\d+\)`, expectedValues: []string{`\(\d+\)`}},
+		{name: "Quoted bar", query: `a "|" b`, expectedQuery: "a|b", expectedValues: []string{}},
+		{name: "Unterminated quote", query: `*"foo bar`, expectedQuery: "bar", expectedValues: []string{`"foo`}},
+		{name: "Lone quote", query: `"`, expectedQuery: `"`, expectedValues: []string{}},
+		{name: "Unbalanced quote", query: `"abc`, expectedQuery: `"abc`, expectedValues: []string{}},
+		{name: "Quote after a phrase", query: `"a b" "c`, expectedQuery: `ab"c`, expectedValues: []string{}},
+		{name: "Escaped quote in an unbalanced one", query: `*"a\"`, expectedValues: []string{`"a"`}},
+		{name: "Trailing backslash", query: `foo\`, expectedQuery: `foo\`, expectedValues: []string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			o := newOptions(nil)
+			query, f := parse(tc.query, &o.syntax)
+			if query != tc.expectedQuery {
+				t.Errorf("Expected query %q, got %q", tc.expectedQuery, query)
+			}
+
+			values := make([]string, len(f))
+			for i, fv := range f {
+				values[i] = fv.value
+			}
+			if !reflect.DeepEqual(values, tc.expectedValues) {
+				t.Errorf("Expected values %q, got %q", tc.expectedValues, values)
+			}
+		})
+	}
+}
+
+func TestFindExpressions(t *testing.T) {
+	source := []string{
+		"main.go", "go.mod", "go.sum", "vendor/lib/lib.go", "cmd/tool/main.go", "internal/util.go", "README.md",
+		`(draft) "notes".txt`,
+	}
+
+	testCases := []struct {
+		query    string
+		expected []int
+	}{
+		{query: "($.go | $.mod) !*vendor", expected: []int{0, 1, 4, 5}},
+		{query: "$.go | $.mod !*vendor", expected: []int{0, 1, 4, 5}},
+		{query: "(^cmd $.go) | *internal", expected: []int{4, 5}},
+		{query: "!(^cmd $.go) $.go", expected: []int{0, 3, 5}},
+		{query: "main ($.go | $.md)", expected: []int{0, 4}},
+		{query: "readme | *sum", expected: []int{2, 6}},
+		{query: "(?^(go|main)\\. | ^readme) !$.md", expected: []int{0, 1, 2}},
+		{query: "(", expected: []int{7}},
+		{query: "()", expected: []int{7}},
+		{query: `"`, expected: []int{7}},
+		{query: `"notes`, expected: []int{7}},
+		{query: "md |", expected: []int{1, 4, 6}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			result := make([]int, 0)
+			for _, m := range Find(tc.query, source) {
+				result = append(result, m.Position)
+			}
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Expected lines %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestFilterGroups(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		line           string
+		expectedResult string
+		expectFound    bool
+	}{
+		{name: "First alternative removes its text", query: "*foo | *bar", line: "foo bar", expectedResult: "bar", expectFound: true},
+		{name: "Second alternative", query: "*xyz | *bar", line: "foo bar", expectedResult: "foo", expectFound: true},
+		{name: "No alternative", query: "*xyz | *abc", line: "foo bar", expectedResult: "foobar", expectFound: false},
+		{name: "Group removes all its text", query: "(^foo $bar) | *o", line: "foo baz bar", expectedResult: "baz", expectFound: true},
+		{name: "Failed group restores the line", query: "(^foo $xyz) | *o", line: "foo baz bar", expectedResult: "fo baz bar", expectFound: true},
+		{name: "Negated group", query: "!(^foo $xyz)", line: "foo bar", expectedResult: "foobar", expectFound: true},
+		{name: "Negated group satisfied", query: "!(^foo $bar)", line: "foo bar", expectFound: false},
+		{name: "Quoted phrase", query: `*"hello world"`, line: "say hello world", expectedResult: "say", expectFound: true},
+		{name: "Quoted phrase not found", query: `*"hello world"`, line: "hello big world", expectFound: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := testQuery(tc.query)
+			result, found := q.filter(tc.line)
+			if found != tc.expectFound {
+				t.Errorf("Expected found=%v, got found=%v", tc.expectFound, found)
+			}
+			if found && result != removeWhitespace(tc.expectedResult) {
+				t.Errorf("Expected result %q, got %q", removeWhitespace(tc.expectedResult), result)
+			}
+		})
+	}
+}
+
+// testQuery parses the query with the options.
+func testQuery(q string, opts ...Option) query {
+	o := newOptions(opts)
+	return newQuery(q, &o)
+}
@@ -0,0 +1,71 @@
+package fuzzy
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Pool is a set of long-lived goroutines that can be shared across chunked searches (see WithPool),
+// so that frequent searches (e.g. one for every keystroke) don't spawn new goroutines every time.
+// A Pool is safe for concurrent use.
+type Pool struct {
+	tasks   chan func()
+	workers int
+	wg      sync.WaitGroup
+	once    sync.Once
+}
+
+// NewPool starts a pool with the given number of goroutines.
+// If workers is less than 1, the number of available CPUs is used.
+// The pool must be closed with Close when it's no longer needed.
+func NewPool(workers int) *Pool {
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	p := &Pool{
+		tasks:   make(chan func()),
+		workers: workers,
+	}
+
+	p.wg.Add(workers)
+	for range workers {
+		go func() {
+			defer p.wg.Done()
+			for task := range p.tasks {
+				task()
+			}
+		}()
+	}
+
+	return p
+}
+
+// Workers returns the number of goroutines of the pool.
+func (p *Pool) Workers() int {
+	return p.workers
+}
+
+// Close stops the goroutines of the pool once the running searches are completed.
+// The pool can't be used after Close.
+func (p *Pool) Close() {
+	p.once.Do(func() {
+		close(p.tasks)
+		p.wg.Wait()
+	})
+}
+
+// tryRun runs the task on a free goroutine of the pool, or on a new goroutine if the pool is nil.
+// It doesn't wait for a goroutine of the pool to be free: it reports false if none is.
+func (p *Pool) tryRun(task func()) bool {
+	if p == nil {
+		go task()
+		return true
+	}
+	select {
+	case p.tasks <- task:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,68 @@
+package fuzzy
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+)
+
+func TestNewPool(t *testing.T) {
+	testCases := []struct {
+		name     string
+		workers  int
+		expected int
+	}{
+		{"Explicit workers", 3, 3},
+		{"Zero workers", 0, runtime.NumCPU()},
+		{"Negative workers", -1, runtime.NumCPU()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewPool(tc.workers)
+			defer p.Close()
+
+			if p.Workers() != tc.expected {
+				t.Errorf("Expected %d workers, got %d", tc.expected, p.Workers())
+			}
+		})
+	}
+}
+
+func TestPoolTryRun(t *testing.T) {
+	p := NewPool(2)
+
+	// the tasks are retried until a goroutine of the pool is free
+	var count atomic.Int64
+	done := make(chan struct{}, 10)
+	for range 10 {
+		for !p.tryRun(func() {
+			count.Add(1)
+			done <- struct{}{}
+		}) {
+			runtime.Gosched()
+		}
+	}
+	for range 10 {
+		<-done
+	}
+
+	// a busy pool rejects the tasks instead of waiting
+	release := make(chan struct{})
+	for range 2 {
+		for !p.tryRun(func() { <-release }) {
+			runtime.Gosched()
+		}
+	}
+	if p.tryRun(func() { count.Add(1) }) {
+		t.Errorf("Expected a busy pool to reject the task")
+	}
+	close(release)
+
+	p.Close()
+	p.Close()
+
+	if count.Load() != 10 {
+		t.Errorf("Expected 10 tasks to run, got %d", count.Load())
+	}
+}
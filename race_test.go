@@ -0,0 +1,7 @@
+//go:build race
+
+package fuzzy
+
+// raceEnabled is true when the tests run with the race detector,
+// which makes sync.Pool drop items at random (and so allocate).
+const raceEnabled = true
@@ -0,0 +1,156 @@
+package fuzzy
+
+import (
+	"slices"
+	"sync"
+	"unicode/utf8"
+)
+
+// Criterion compares two matches for ranking purposes.
+// It returns a negative number if a should come before b, a positive number if b should come before a
+// and zero if the criterion can't decide (the next criterion is used in that case).
+// The source is the slice the matches refer to (e.g. source[a.Position]).
+type Criterion func(source []string, a, b Match) int
+
+// Rank sorts the matches using the given criteria, in order: the first criterion decides the order,
+// the following ones are used only to break the ties of the previous ones.
+// If all the criteria are equal the original order of the matches is preserved.
+//
+// The source must be the slice used for the search, it's needed by the criteria that look at the
+// matched lines (e.g. ByLength, ByNatural); it can be nil if only ByScore and ByPosition are used.
+//
+// e.g. Rank(matches, source, ByScore, ByLength, ByMatchStart(query, opts...), ByNatural)
+// e.g. Rank(matches, source, Descending(ByScore), ByPosition)
+func Rank(m []Match, source []string, criteria ...Criterion) []Match {
+	slices.SortStableFunc(m, func(a, b Match) int {
+		for _, c := range criteria {
+			if r := c(source, a, b); r != 0 {
+				return r
+			}
+		}
+		return 0
+	})
+	return m
+}
+
+// ByScore orders the matches by score (lower first).
+func ByScore(_ []string, a, b Match) int {
+	return a.Score - b.Score
+}
+
+// ByPosition orders the matches by their position in the source (earlier first).
+func ByPosition(_ []string, a, b Match) int {
+	return a.Position - b.Position
+}
+
+// ByLength orders the matches by the length in runes of the matched line (shorter first).
+func ByLength(source []string, a, b Match) int {
+	return utf8.RuneCountInString(source[a.Position]) - utf8.RuneCountInString(source[b.Position])
+}
+
+// ByNatural orders the matches by the natural order of the matched lines,
+// comparing the sequences of digits by their numeric value (e.g. "file2" before "file10").
+func ByNatural(source []string, a, b Match) int {
+	return naturalCompare(source[a.Position], source[b.Position])
+}
+
+// ByMatchStart orders the matches by the offset in the line where the query starts to match (earlier first),
+// that is the first of the Positions reported by Explain. The lines without positions come last.
+// The query and the options must be the ones used for the search (e.g. WithSyntax or WithFolding).
+// The criterion remembers the start of every line it compares, so it can be used with any source
+// and by concurrent rankings, but it's meant to be created for a ranking: it keeps every line it has seen.
+func ByMatchStart(queryValue string, opts ...Option) Criterion {
+	o := newOptions(opts)
+	q := newQuery(queryValue, &o)
+
+	var mu sync.Mutex
+	starts := make(map[string]int)
+	start := func(line string) int {
+		mu.Lock()
+		s, ok := starts[line]
+		mu.Unlock()
+		if ok {
+			return s
+		}
+
+		s = len(line)
+		if p := explainQuery(&q, line).Positions; len(p) > 0 {
+			s = p[0]
+		}
+		mu.Lock()
+		starts[line] = s
+		mu.Unlock()
+		return s
+	}
+
+	return func(source []string, a, b Match) int {
+		return start(source[a.Position]) - start(source[b.Position])
+	}
+}
+
+// Descending reverses the order of the criterion.
+func Descending(c Criterion) Criterion {
+	return func(source []string, a, b Match) int {
+		return c(source, b, a)
+	}
+}
+
+// naturalCompare compares two strings treating the sequences of digits as numbers.
+func naturalCompare(a, b string) int {
+	for a != "" && b != "" {
+		if isDigit(a[0]) && isDigit(b[0]) {
+			na, nb := digits(a), digits(b)
+			if r := compareNumbers(a[:na], b[:nb]); r != 0 {
+				return r
+			}
+			a, b = a[na:], b[nb:]
+			continue
+		}
+
+		ra, sa := utf8.DecodeRuneInString(a)
+		rb, sb := utf8.DecodeRuneInString(b)
+		if ra != rb {
+			return int(ra) - int(rb)
+		}
+		a, b = a[sa:], b[sb:]
+	}
+
+	return len(a) - len(b)
+}
+
+// compareNumbers compares two sequences of digits by their numeric value.
+// If the values are equal, the sequence with fewer leading zeros comes first.
+func compareNumbers(a, b string) int {
+	ta, tb := trimZeros(a), trimZeros(b)
+	if len(ta) != len(tb) {
+		return len(ta) - len(tb)
+	}
+	for i := range len(ta) {
+		if ta[i] != tb[i] {
+			return int(ta[i]) - int(tb[i])
+		}
+	}
+	return len(a) - len(b)
+}
+
+// trimZeros removes the leading zeros from a sequence of digits.
+func trimZeros(s string) string {
+	for len(s) > 1 && s[0] == '0' {
+		s = s[1:]
+	}
+	return s
+}
+
+// digits returns the length of the sequence of digits at the start of the string.
+func digits(s string) int {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return i
+}
+
+// isDigit checks if the byte is an ASCII digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
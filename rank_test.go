@@ -0,0 +1,166 @@
+package fuzzy
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestRank(t *testing.T) {
+	source := []string{"file10.go", "file2.go", "a/file.go", "file.go", "xfile.go"}
+
+	testCases := []struct {
+		name     string
+		input    []Match
+		criteria []Criterion
+		expected []Match
+	}{
+		{
+			name:     "Score then position",
+			input:    []Match{{Score: 3, Position: 1}, {Score: 1, Position: 2}, {Score: 1, Position: 0}},
+			criteria: []Criterion{ByScore, ByPosition},
+			expected: []Match{{Score: 1, Position: 0}, {Score: 1, Position: 2}, {Score: 3, Position: 1}},
+		},
+		{
+			name:     "Descending score",
+			input:    []Match{{Score: 1, Position: 0}, {Score: 3, Position: 1}, {Score: 2, Position: 2}},
+			criteria: []Criterion{Descending(ByScore)},
+			expected: []Match{{Score: 3, Position: 1}, {Score: 2, Position: 2}, {Score: 1, Position: 0}},
+		},
+		{
+			name:     "Ties broken by length",
+			input:    []Match{{Score: 1, Position: 0}, {Score: 1, Position: 3}, {Score: 1, Position: 1}},
+			criteria: []Criterion{ByScore, ByLength},
+			expected: []Match{{Score: 1, Position: 3}, {Score: 1, Position: 1}, {Score: 1, Position: 0}},
+		},
+		{
+			name:     "Ties broken by natural order",
+			input:    []Match{{Score: 0, Position: 0}, {Score: 0, Position: 1}},
+			criteria: []Criterion{ByScore, ByNatural},
+			expected: []Match{{Score: 0, Position: 1}, {Score: 0, Position: 0}},
+		},
+		{
+			name:     "Ties broken by match start",
+			input:    []Match{{Score: 1, Position: 4}, {Score: 1, Position: 2}, {Score: 1, Position: 3}},
+			criteria: []Criterion{ByScore, ByMatchStart("file")},
+			expected: []Match{{Score: 1, Position: 3}, {Score: 1, Position: 4}, {Score: 1, Position: 2}},
+		},
+		{
+			name:     "Custom criterion",
+			input:    []Match{{Score: 2, Position: 3}, {Score: 1, Position: 0}},
+			criteria: []Criterion{func(_ []string, a, b Match) int { return b.Position - a.Position }},
+			expected: []Match{{Score: 2, Position: 3}, {Score: 1, Position: 0}},
+		},
+		{
+			name:     "No criteria keeps the order",
+			input:    []Match{{Score: 2, Position: 3}, {Score: 1, Position: 0}},
+			expected: []Match{{Score: 2, Position: 3}, {Score: 1, Position: 0}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := Rank(tc.input, source, tc.criteria...)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestByMatchStart(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		opts     []Option
+		source   []string
+		expected []int
+	}{
+		{name: "Text", query: "file", source: []string{"a/file.go", "file.go", "xfile.go"}, expected: []int{1, 2, 0}},
+		{name: "Folding", query: "citta", opts: []Option{WithFolding(FoldMarks)}, source: []string{"la città", "città", "a città"}, expected: []int{1, 2, 0}},
+		{name: "Terms", query: "go main", opts: []Option{WithSyntax(SyntaxTerms)}, source: []string{"x main go", "main.go", "xx go main"}, expected: []int{1, 0, 2}},
+		{name: "fzf", query: "'go !y", opts: []Option{WithSyntax(SyntaxFZF)}, source: []string{"xx go", "go", "x go"}, expected: []int{1, 2, 0}},
+		{name: "Filters", query: "main $.go", source: []string{"a main.go", "main.go"}, expected: []int{1, 0}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := make([]Match, len(tc.source))
+			for i := range m {
+				m[i] = Match{Position: i}
+			}
+			result := make([]int, 0)
+			for _, r := range Rank(m, tc.source, ByMatchStart(tc.query, tc.opts...)) {
+				result = append(result, r.Position)
+			}
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestByMatchStartReuse(t *testing.T) {
+	c := ByMatchStart("go")
+	first, second := []string{"go", "xxgo"}, []string{"xxgo", "go"}
+
+	// the starts are remembered by line, so the criterion works with any source
+	for range 2 {
+		for _, source := range [][]string{first, second} {
+			m := Rank([]Match{{Position: 0}, {Position: 1}}, source, c)
+			if source[m[0].Position] != "go" {
+				t.Errorf("Expected \"go\" first in %v, got %v", source, m)
+			}
+		}
+	}
+
+	// and it can be shared by concurrent rankings
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			source := []string{fmt.Sprintf("%dxgo", i), "go", fmt.Sprintf("x%dgo", i)}
+			if m := Rank([]Match{{Position: 0}, {Position: 1}, {Position: 2}}, source, c); m[0].Position != 1 {
+				t.Errorf("Expected \"go\" first in %v, got %v", source, m)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestNaturalCompare(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"file2", "file10", -1},
+		{"file10", "file2", 1},
+		{"file2", "file2", 0},
+		{"file02", "file2", 1},
+		{"a", "b", -1},
+		{"file", "file1", -1},
+		{"v1.10.0", "v1.9.3", 1},
+		{"città2", "città10", -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			result := naturalCompare(tc.a, tc.b)
+			if sign(result) != tc.expected {
+				t.Errorf("Expected %d, got %d", tc.expected, result)
+			}
+		})
+	}
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
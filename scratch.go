@@ -0,0 +1,36 @@
+package fuzzy
+
+import "sync"
+
+// scratch is the working memory of a search: it's reused across lines and searches
+// so that scoring a line doesn't allocate.
+type scratch struct {
+	buf   []byte
+	col   []int
+	stats *Stats // the statistics of the goroutine using the scratch, if they are collected
+}
+
+// scratchPool holds the scratches not in use.
+var scratchPool = sync.Pool{
+	New: func() any {
+		return &scratch{}
+	},
+}
+
+// getScratch takes a scratch from the pool.
+func getScratch() *scratch {
+	return scratchPool.Get().(*scratch)
+}
+
+// putScratch gives the scratch back to the pool.
+func putScratch(sc *scratch) {
+	scratchPool.Put(sc)
+}
+
+// column returns the column used by the Levenshtein algorithm for a query of n bytes.
+func (sc *scratch) column(n int) []int {
+	if cap(sc.col) < n+1 {
+		sc.col = make([]int, n+1)
+	}
+	return sc.col[:n+1]
+}
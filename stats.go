@@ -0,0 +1,108 @@
+package fuzzy
+
+import (
+	"bytes"
+	"strings"
+	"time"
+)
+
+// Stats are the statistics of a search, collected with the WithStats and WithStatsHook options.
+// They help to understand where the time of a slow search goes (e.g. regex filters, long lines
+// or Levenshtein distances).
+type Stats struct {
+	Lines     int             // the lines scanned
+	Filtered  int             // the lines rejected by the filters
+	TooShort  int             // the lines rejected because (once normalized) they are shorter than the query
+	Exact     int             // the lines equal to the query (or scored with an empty query)
+	Substring int             // the lines containing the query
+	Fuzzy     int             // the lines scanned rune by rune looking for the query (standard algorithm)
+	FullDP    int             // the lines scored computing the full Levenshtein distance (Levenshtein algorithm)
+	Matches   int             // the lines matching the query
+	Chunks    []time.Duration // the time spent by every goroutine of the search
+	Duration  time.Duration   // the total time of the search
+}
+
+// StatsHook receives the statistics of the searches, see WithStatsHook.
+type StatsHook interface {
+	Record(Stats)
+}
+
+// StatsHookFunc is a function used as a StatsHook.
+type StatsHookFunc func(Stats)
+
+// Record calls f(s).
+func (f StatsHookFunc) Record(s Stats) {
+	f(s)
+}
+
+// filtered counts a line rejected by the filters, if the statistics are collected.
+// It always returns -1, the score of the line.
+func (s *Stats) filtered() int {
+	if s != nil {
+		s.Lines++
+		s.Filtered++
+	}
+	return -1
+}
+
+// scored counts a line scored by a query of terms, which isn't split by the branch of the algorithm.
+func (s *Stats) scored(score int) int {
+	if s != nil {
+		s.Lines++
+		if score >= 0 {
+			s.Matches++
+		}
+	}
+	return score
+}
+
+// record counts a line that passed the filters, given its normalized form and its score.
+func record[T string | []byte](s *Stats, a algorithm, q string, line T, score int) {
+	s.Lines++
+	if score >= 0 {
+		s.Matches++
+	}
+
+	switch {
+	case len(line) < len(q):
+		s.TooShort++
+	case string(line) == q, q == "":
+		s.Exact++
+	case contains(line, q):
+		s.Substring++
+	case a == standard:
+		s.Fuzzy++
+	case score >= 0:
+		// the Levenshtein algorithm computes the distance only if the line contains most of the query
+		s.FullDP++
+	}
+}
+
+// contains checks if the line contains the query.
+func contains[T string | []byte](line T, q string) bool {
+	switch l := any(line).(type) {
+	case []byte:
+		return bytes.Contains(l, []byte(q))
+	case string:
+		return strings.Contains(l, q)
+	}
+	return false
+}
+
+// mergeStats sums the statistics of the goroutines of a search.
+func mergeStats(stats []Stats, d time.Duration) Stats {
+	var r Stats
+	for _, s := range stats {
+		r.Lines += s.Lines
+		r.Filtered += s.Filtered
+		r.TooShort += s.TooShort
+		r.Exact += s.Exact
+		r.Substring += s.Substring
+		r.Fuzzy += s.Fuzzy
+		r.FullDP += s.FullDP
+		r.Matches += s.Matches
+		r.Chunks = append(r.Chunks, s.Chunks...)
+	}
+	r.Duration = d
+	return r
+}
@@ -0,0 +1,135 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	source := []string{"cart", "ca", "clap", "dog", "c", "a cat", "nope"}
+
+	testCases := []struct {
+		name     string
+		query    string
+		algo     algorithm
+		expected Stats
+	}{
+		{name: "Standard", query: "ca", algo: standard, expected: Stats{Lines: 7, TooShort: 1, Exact: 1, Substring: 2, Fuzzy: 3, Matches: 4}},
+		{name: "Levenshtein", query: "ca", algo: levenshtein, expected: Stats{Lines: 7, TooShort: 1, Exact: 1, Substring: 2, FullDP: 1, Matches: 4}},
+		{name: "Suffix filter", query: "ca $t", algo: standard, expected: Stats{Lines: 7, Filtered: 5, Substring: 2, Matches: 2}},
+		{name: "Regex filter", query: "ca ?^c", algo: standard, expected: Stats{Lines: 7, Filtered: 3, TooShort: 1, Exact: 1, Substring: 1, Fuzzy: 1, Matches: 3}},
+		{name: "Case sensitive", query: "Ca", algo: standard, expected: Stats{Lines: 7, TooShort: 1, Fuzzy: 6}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var result Stats
+			o := newOptions([]Option{WithStats(&result)})
+			q := newQuery(tc.query, &o)
+			m := find(nil, &q, source, tc.algo, &o)
+
+			if result.Matches != len(m) {
+				t.Errorf("Expected %d matches in the stats, got %d", len(m), result.Matches)
+			}
+			if len(result.Chunks) != 1 || result.Duration <= 0 {
+				t.Errorf("Expected 1 chunk and a positive duration, got %v and %v", result.Chunks, result.Duration)
+			}
+
+			result.Chunks, result.Duration = nil, 0
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, result)
+			}
+
+			// the searches on the cached lines count the lines in the same way
+			c, a := NewCorpus(source), NewArena(source)
+			search := map[algorithm][2]func(string, ...Option) []Match{
+				standard:    {c.Find, a.Find},
+				levenshtein: {c.LevenshteinFind, a.LevenshteinFind},
+			}
+			for i, f := range search[tc.algo] {
+				var s Stats
+				f(tc.query, WithStats(&s))
+				s.Chunks, s.Duration = nil, 0
+				if !reflect.DeepEqual(s, tc.expected) {
+					t.Errorf("Expected %+v from the search %d on the cached lines, got %+v", tc.expected, i, s)
+				}
+			}
+		})
+	}
+}
+
+func TestChunkFindStats(t *testing.T) {
+	source := pathSource(5000)
+
+	var expected Stats
+	Find("src", source, WithStats(&expected))
+
+	var result []Stats
+	hook := StatsHookFunc(func(s Stats) {
+		result = append(result, s)
+	})
+	ChunkFind("src", source, WithStatsHook(hook), WithWorkers(4), WithMinChunkSize(100))
+
+	if len(result) != 1 {
+		t.Fatalf("Expected the hook to be called once, got %d", len(result))
+	}
+	if len(result[0].Chunks) != 4 {
+		t.Errorf("Expected 4 chunks, got %d", len(result[0].Chunks))
+	}
+	for _, d := range result[0].Chunks {
+		if d > result[0].Duration {
+			t.Errorf("Expected the chunks to be shorter than the search (%v), got %v", result[0].Duration, d)
+		}
+	}
+
+	result[0].Chunks, result[0].Duration = nil, 0
+	expected.Chunks, expected.Duration = nil, 0
+	if !reflect.DeepEqual(result[0], expected) {
+		t.Errorf("Expected %+v, got %+v", expected, result[0])
+	}
+}
+
+func TestReusedSearchStats(t *testing.T) {
+	source := []string{"cart", "ca", "clap", "dog"}
+
+	// an incremental search counts the lines it scores again
+	in := NewIncremental(source)
+	var s Stats
+	in.Find("c", WithStats(&s))
+	if s.Lines != 4 || s.Matches != 3 {
+		t.Errorf("Expected 4 lines and 3 matches, got %+v", s)
+	}
+	in.Find("ca", WithStats(&s))
+	if s.Lines != 3 || s.Matches != 3 {
+		t.Errorf("Expected the 3 previous matches to be scored again, got %+v", s)
+	}
+
+	// a cache hit scans no lines
+	c := NewCache(100)
+	c.Find(1, "ca", source, WithStats(&s))
+	if s.Lines != 4 || s.Matches != 3 {
+		t.Errorf("Expected a miss to scan the source, got %+v", s)
+	}
+	var hits int
+	c.Find(1, "ca", source, WithStats(&s), WithStatsHook(StatsHookFunc(func(Stats) { hits++ })))
+	if s.Lines != 0 || s.Matches != 3 || hits != 1 {
+		t.Errorf("Expected a hit to report its matches without lines, got %+v (%d reports)", s, hits)
+	}
+}
+
+func TestStatsDisabled(t *testing.T) {
+	// without the options the scratch must not keep collecting into a stale Stats
+	var s Stats
+	Find("ca", []string{"cart"}, WithStats(&s))
+	Find("ca", []string{"cart", "clap"})
+
+	if s.Lines != 1 {
+		t.Errorf("Expected 1 line, got %d", s.Lines)
+	}
+
+	sc := getScratch()
+	defer putScratch(sc)
+	if sc.stats != nil {
+		t.Errorf("Expected a scratch without stats, got %+v", sc.stats)
+	}
+}
@@ -0,0 +1,106 @@
+package fuzzy
+
+import "unicode/utf8"
+
+// Operators are the characters that start the filters of a query (see WithOperators).
+// A zero character disables the operator, so the words starting with its character are text.
+type Operators struct {
+	Contains rune // the line contains the value, "*" by default
+	Suffix   rune // the line ends with the value, "$" by default
+	Prefix   rune // the line starts with the value, "^" by default
+	Regex    rune // the line matches the regex, "?" by default
+	Not      rune // negates a filter or a group, "!" by default
+	Optional rune // marks a word as an optional term with SyntaxTerms, "~" by default
+}
+
+// defaultOperators are the operators used when WithOperators isn't set.
+var defaultOperators = Operators{Contains: '*', Suffix: '$', Prefix: '^', Regex: '?', Not: '!', Optional: '~'}
+
+// DefaultOperators returns the operators used when WithOperators isn't set.
+// It returns a copy, so it can be changed and passed to WithOperators.
+func DefaultOperators() Operators {
+	return defaultOperators
+}
+
+// Syntax is the grammar of the queries (see WithSyntax).
+type Syntax int
+
+const (
+	// SyntaxDefault is the syntax of this package: the words of the query are joined in a single text
+	// to match, with filters, groups and alternations (see the Query Syntax section of the README).
+	SyntaxDefault Syntax = iota
+	// SyntaxFZF is the extended-search syntax of fzf: every word of the query is a term matched on its own
+	// ("foo" fuzzy, "'foo" exact, "^foo" prefix, "foo$" suffix, "!foo" inverse, "a | b" alternation).
+	SyntaxFZF
+	// SyntaxTerms is the default syntax, but every word of the text is a term matched on its own, in any order:
+	// "config test" matches "test/config.go", and the line must match all the terms. A word starting with "~"
+	// (see Operators.Optional) is an optional term, that only ranks the lines matching it above the others.
+	SyntaxTerms
+)
+
+// syntax is the configuration of the query parser.
+// The queries keep their syntax, since the same query can have different meanings with different syntaxes.
+type syntax struct {
+	grammar Syntax
+	ops     Operators
+	literal bool // the whole query is text
+	casing  Case
+	folding Folding
+}
+
+// terms checks if the words of the text are parsed as terms (see SyntaxTerms).
+func (s *syntax) terms() bool {
+	return s.grammar == SyntaxTerms && !s.literal
+}
+
+// fzf checks if the queries are parsed with the fzf syntax.
+func (s *syntax) fzf() bool {
+	return s.grammar == SyntaxFZF && !s.literal
+}
+
+// operator returns the filter operator of the character ('*', '$', '^' or '?'), or 0 if it isn't an operator.
+func (s *syntax) operator(r rune) byte {
+	switch r {
+	case 0, utf8.RuneError:
+		return 0
+	case s.ops.Contains:
+		return '*'
+	case s.ops.Suffix:
+		return '$'
+	case s.ops.Prefix:
+		return '^'
+	case s.ops.Regex:
+		return '?'
+	}
+	return 0
+}
+
+// cutOptional removes the optional operator from the start of the word, reporting if it was there.
+// A word made only of the operator isn't optional.
+func (s *syntax) cutOptional(w string) (string, bool) {
+	if r, size := utf8.DecodeRuneInString(w); s.ops.Optional != 0 && r == s.ops.Optional && len(w) > size {
+		return w[size:], true
+	}
+	return w, false
+}
+
+// cutNot removes the negation operator from the start of the word, reporting if it was there.
+func (s *syntax) cutNot(w string) (string, bool) {
+	if r, size := utf8.DecodeRuneInString(w); s.ops.Not != 0 && r == s.ops.Not {
+		return w[size:], true
+	}
+	return w, false
+}
+
+// isFilter checks if the word (as written in the query) is a filter.
+func (s *syntax) isFilter(w string) bool {
+	r, _ := utf8.DecodeRuneInString(w)
+	return s.operator(r) != 0 || (s.ops.Not != 0 && r == s.ops.Not)
+}
+
+// isRegex checks if the word (as written in the query) is a regex filter, negated or not.
+func (s *syntax) isRegex(w string) bool {
+	w, _ = s.cutNot(w)
+	r, _ := utf8.DecodeRuneInString(w)
+	return s.operator(r) == '?'
+}
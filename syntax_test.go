@@ -0,0 +1,100 @@
+package fuzzy
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestOperators(t *testing.T) {
+	source := []string{"price $12", "$12.00", "main.go", "go.mod", "vendor/lib.go", "what?", "a*b", "§ section"}
+
+	remapped := DefaultOperators()
+	remapped.Suffix = '%'
+	remapped.Not = '~'
+
+	noRegex := DefaultOperators()
+	noRegex.Regex = 0
+
+	unicode := Operators{Contains: '§', Suffix: '€'}
+
+	noPrefix := DefaultOperators()
+	noPrefix.Prefix = 0
+
+	testCases := []struct {
+		name     string
+		query    string
+		opts     []Option
+		expected []int
+	}{
+		{name: "Default suffix", query: "$12", expected: []int{0}},
+		{name: "Remapped suffix is text", query: "$12", opts: []Option{WithOperators(remapped)}, expected: []int{0, 1}},
+		{name: "Remapped suffix", query: "%.go", opts: []Option{WithOperators(remapped)}, expected: []int{2, 4}},
+		{name: "Remapped negation", query: "%.go ~*vendor", opts: []Option{WithOperators(remapped)}, expected: []int{2}},
+		{name: "Remapped negated group", query: "~(*main | *vendor) %.go", opts: []Option{WithOperators(remapped)}, expected: []int{}},
+		{name: "Old negation is text", query: "!*vendor", opts: []Option{WithOperators(remapped)}, expected: []int{}},
+		{name: "Negated text", query: "go !.go", expected: []int{3}},
+		{name: "Disabled prefix is negated text", query: "go !^main", opts: []Option{WithOperators(noPrefix)}, expected: []int{2, 3, 4}},
+		{name: "Disabled regex", query: "what?", opts: []Option{WithOperators(noRegex)}, expected: []int{5}},
+		{name: "Unicode operators", query: "§lib €.go", opts: []Option{WithOperators(unicode)}, expected: []int{4}},
+		{name: "Disabled operators are text", query: "a*b", opts: []Option{WithOperators(unicode)}, expected: []int{6}},
+		{name: "Literal", query: "$12.00", opts: []Option{WithLiteral()}, expected: []int{1}},
+		{name: "Literal with spaces", query: "price $12", opts: []Option{WithLiteral()}, expected: []int{0}},
+		{name: "Literal without groups", query: "(main | go)", opts: []Option{WithLiteral()}, expected: []int{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := make([]int, 0)
+			for _, m := range Find(tc.query, source, tc.opts...) {
+				result = append(result, m.Position)
+			}
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Expected lines %v, got %v", tc.expected, result)
+			}
+
+			// every entry point must use the same syntax
+			c := NewCorpus(source)
+			if corpus := c.Find(tc.query, tc.opts...); !reflect.DeepEqual(corpus, Find(tc.query, source, tc.opts...)) {
+				t.Errorf("Corpus.Find: expected the same matches of Find, got %v", corpus)
+			}
+			if score, expected := MatchScore(tc.query, source[0], tc.opts...), slices.Contains(tc.expected, 0); (score >= 0) != expected {
+				t.Errorf("MatchScore: expected a match %v, got the score %d", expected, score)
+			}
+		})
+	}
+}
+
+func TestSyntaxKeys(t *testing.T) {
+	source := []string{"price $12", "x12"}
+	remapped := DefaultOperators()
+	remapped.Suffix = '%'
+
+	t.Run("Cache", func(t *testing.T) {
+		c := NewCache(100)
+		c.Find(1, "$12", source)
+		m := c.Find(1, "$12", source, WithOperators(remapped))
+		if stats := c.Stats(); stats.Misses != 2 {
+			t.Errorf("Expected 2 misses for different syntaxes, got %d", stats.Misses)
+		}
+		if len(m) != 1 || m[0].Position != 0 {
+			t.Errorf("Expected the match of the remapped syntax, got %v", m)
+		}
+	})
+
+	t.Run("Incremental", func(t *testing.T) {
+		in := NewIncremental(source)
+		in.Find("$1")
+		m := in.Find("$12", WithOperators(remapped))
+		if expected := Find("$12", source, WithOperators(remapped)); !reflect.DeepEqual(m, expected) {
+			t.Errorf("Expected %v, got %v", expected, m)
+		}
+	})
+
+	t.Run("Explain", func(t *testing.T) {
+		e := Explain("%2 $1", "price $12", WithOperators(remapped))
+		if e.Query != "$1" || len(e.Filters) != 1 || e.Filters[0].Filter != "%2" || e.Filters[0].Removed != "2" {
+			t.Errorf("Expected the text $1 and the filter %%2, got %+v", e)
+		}
+	})
+}
@@ -0,0 +1,182 @@
+package fuzzy
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// termKind is the way a term is matched against a line.
+type termKind int
+
+const (
+	termFuzzy    termKind = iota // the runes of the term appear in order (scored with the algorithm of the search)
+	termExact                    // the line contains the term
+	termBoundary                 // the line contains the term as a whole word
+	termPrefix                   // the line starts with the term
+	termSuffix                   // the line ends with the term
+	termEqual                    // the line is equal to the term
+)
+
+// term is a part of a query matched on its own (e.g. in the fzf syntax).
+type term struct {
+	raw      string // the term as written in the query
+	kind     termKind
+	value    string
+	inverse  bool // the line must not match the term
+	upper    bool // the term is case sensitive
+	optional bool // the line doesn't need to match the term, it only ranks better if it does (see SyntaxTerms)
+}
+
+// termLine holds the forms of a line scored by the terms of a query, computed only when a term needs them.
+// The index is 1 for the case sensitive terms and 0 for the others.
+type termLine struct {
+	line      [2]string // the line lowercased and as is
+	compact   [2]string // the line without whitespace, for the fuzzy terms
+	compacted [2]bool
+}
+
+// scoreTerms calculates the score of a line for a query made of terms, given the line and its lowercased form
+// (which isn't used if every term is case sensitive).
+// Every group of terms is satisfied if at least one of its terms matches the line, and the line
+// must satisfy all the groups: its score is the sum of the best score of every group.
+// An optional term missing from the line adds twice the length of the line plus one to the score,
+// which is more than any match of the term in the same line.
+func (a algorithm) scoreTerms(q *query, s, lower string, sc *scratch) int {
+	l := termLine{line: [2]string{lower, s}}
+
+	total := 0
+	for _, group := range q.terms {
+		best := -1
+		for i := range group {
+			if score := group[i].score(a, &l, sc); score >= 0 && (best < 0 || score < best) {
+				best = score
+			}
+		}
+		switch {
+		case best >= 0:
+			total += best
+		case group[0].optional:
+			total += 2*len(s) + 1
+		default:
+			return -1
+		}
+	}
+
+	return total
+}
+
+// score calculates the score of the term in the line, or -1 if the line doesn't satisfy the term.
+// The fuzzy terms are scored by the algorithm on the line without whitespace, the others score
+// the length of the line not covered by the term. The inverse terms always score 0.
+func (t *term) score(a algorithm, l *termLine, sc *scratch) int {
+	c := 0
+	if t.upper {
+		c = 1
+	}
+	line := l.line[c]
+
+	var found bool
+	switch t.kind {
+	case termFuzzy:
+		if !l.compacted[c] {
+			l.compact[c], l.compacted[c] = removeWhitespace(line), true
+		}
+		score := a.scoreNormalized(t.value, l.compact[c], sc)
+		if !t.inverse {
+			return score
+		}
+		found = score >= 0
+	case termExact:
+		found = strings.Contains(line, t.value)
+	case termBoundary:
+		found = containsWord(line, t.value)
+	case termPrefix:
+		found = strings.HasPrefix(trimFor(line, t.value, strings.TrimLeftFunc), t.value)
+	case termSuffix:
+		found = strings.HasSuffix(trimFor(line, t.value, strings.TrimRightFunc), t.value)
+	case termEqual:
+		found = strings.TrimSpace(line) == t.value
+	}
+
+	switch {
+	case found == t.inverse:
+		return -1
+	case t.inverse:
+		return 0
+	}
+	return len(line) - len(t.value)
+}
+
+// trimFor trims the whitespace from one side of the line, unless the term starts or ends with whitespace
+// (e.g. "^foo" matches "  foo bar", while "^\ foo" needs the whitespace).
+func trimFor(line, value string, trim func(string, func(rune) bool) string) string {
+	first, _ := utf8.DecodeRuneInString(value)
+	last, _ := utf8.DecodeLastRuneInString(value)
+	if unicode.IsSpace(first) || unicode.IsSpace(last) {
+		return line
+	}
+	return trim(line, unicode.IsSpace)
+}
+
+// containsWord checks if the line contains the value between word boundaries
+// (the edges of the line or characters that aren't letters or digits).
+func containsWord(line, value string) bool {
+	return indexWord(line, value) >= 0
+}
+
+// indexWord returns the offset of the first occurrence of the value between word boundaries, or -1.
+func indexWord(line, value string) int {
+	for start := 0; start <= len(line); {
+		i := strings.Index(line[start:], value)
+		if i < 0 {
+			return -1
+		}
+		i += start
+
+		before, _ := utf8.DecodeLastRuneInString(line[:i])
+		after, _ := utf8.DecodeRuneInString(line[i+len(value):])
+		if !isWordRune(before) && !isWordRune(after) {
+			return i
+		}
+
+		_, size := utf8.DecodeRuneInString(line[i:])
+		start = i + max(size, 1)
+	}
+	return -1
+}
+
+// isWordRune checks if the rune is part of a word (a letter or a digit).
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// newTermQuery creates a query from its filters and terms.
+// The lines don't need to be lowercased if every term is case sensitive, and they keep their case through
+// the filters if any term or filter is. A query without terms is a query without text.
+func newTermQuery(f []filter, terms [][]term, o *options) query {
+	if terms == nil {
+		return makeQuery("", f, o)
+	}
+
+	upper, keep := true, sensitiveFilters(f)
+	for _, group := range terms {
+		for _, t := range group {
+			upper = upper && t.upper
+			keep = keep || t.upper
+		}
+	}
+	fold(f, keep)
+	return query{filters: f, terms: terms, upper: upper, keep: keep, syntax: o.syntax}
+}
+
+// termsKey writes the terms of a query to the key of the query (see query.key).
+func termsKey(b *strings.Builder, terms [][]term) {
+	writeCount(b, len(terms))
+	for _, group := range terms {
+		writeCount(b, len(group))
+		for _, t := range group {
+			writeKey(b, t.raw)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTerms(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		filters  string
+		expected [][]term
+	}{
+		{name: "Empty", query: "", filters: "", expected: nil},
+		{name: "Words", query: "config test", filters: "", expected: [][]term{
+			{{raw: "config", kind: termFuzzy, value: "config"}},
+			{{raw: "test", kind: termFuzzy, value: "test"}},
+		}},
+		{name: "Filters", query: "config $.go !*vendor", filters: "$.go !*vendor", expected: [][]term{
+			{{raw: "config", kind: termFuzzy, value: "config"}},
+		}},
+		{name: "Optional", query: "config ~test", filters: "", expected: [][]term{
+			{{raw: "config", kind: termFuzzy, value: "config"}},
+			{{raw: "~test", kind: termFuzzy, value: "test", optional: true}},
+		}},
+		{name: "Escaped optional", query: `\~test`, filters: "", expected: [][]term{
+			{{raw: `\~test`, kind: termFuzzy, value: "~test"}},
+		}},
+		{name: "Lone optional operator", query: "~", filters: "", expected: [][]term{
+			{{raw: "~", kind: termFuzzy, value: "~"}},
+		}},
+		{name: "Phrase", query: `"foo bar" baz`, filters: "", expected: [][]term{
+			{{raw: `"foo bar"`, kind: termFuzzy, value: "foobar"}},
+			{{raw: "baz", kind: termFuzzy, value: "baz"}},
+		}},
+		{name: "Smart case", query: "Config test", filters: "", expected: [][]term{
+			{{raw: "Config", kind: termFuzzy, value: "Config", upper: true}},
+			{{raw: "test", kind: termFuzzy, value: "test"}},
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			o := newOptions(nil)
+			f, terms := parseTerms(tc.query, &o.syntax)
+			if raw := joinRaw(f, " "); raw != tc.filters {
+				t.Errorf("Expected the filters %q, got %q", tc.filters, raw)
+			}
+			if !reflect.DeepEqual(terms, tc.expected) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, terms)
+			}
+		})
+	}
+}
+
+func TestFindTerms(t *testing.T) {
+	source := []string{
+		"test/config.go",
+		"src/config.go",
+		"src/config_test.go",
+		"vendor/test/config.go",
+		"README.md",
+		"Test/Config.md",
+	}
+
+	testCases := []struct {
+		name     string
+		query    string
+		expected []int
+	}{
+		{name: "Any order", query: "config test", expected: []int{0, 2, 3, 5}},
+		{name: "Joined words", query: "configtest", expected: []int{2}},
+		{name: "Filters", query: "config test !*vendor $.go", expected: []int{0, 2}},
+		{name: "Optional terms don't filter", query: "config ~test", expected: []int{0, 1, 2, 3, 5}},
+		{name: "Only optional terms", query: "~readme", expected: []int{0, 1, 2, 3, 4, 5}},
+		{name: "Case sensitive term", query: "Config test", expected: []int{5}},
+		{name: "Case sensitive terms", query: "Config Test", expected: []int{5}},
+		{name: "Case sensitive filter", query: "config *Test", expected: []int{5}},
+		{name: "Only filters", query: "^src", expected: []int{1, 2}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := []Option{WithSyntax(SyntaxTerms)}
+			result := make([]int, 0)
+			for _, m := range Find(tc.query, source, opts...) {
+				result = append(result, m.Position)
+			}
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Expected lines %v, got %v", tc.expected, result)
+			}
+
+			// every entry point must use the same syntax
+			expected := Find(tc.query, source, opts...)
+			if m := NewCorpus(source).Find(tc.query, opts...); !reflect.DeepEqual(m, expected) {
+				t.Errorf("Corpus.Find: expected %v, got %v", expected, m)
+			}
+			if m := NewArena(source).Find(tc.query, opts...); !reflect.DeepEqual(m, expected) {
+				t.Errorf("Arena.Find: expected %v, got %v", expected, m)
+			}
+			if m, err := SafeFind(tc.query, source, Limits{}, opts...); err != nil || !reflect.DeepEqual(m, expected) {
+				t.Errorf("SafeFind: expected %v, got %v %v", expected, m, err)
+			}
+			for _, m := range expected {
+				if score := MatchScore(tc.query, source[m.Position], opts...); score != m.Score {
+					t.Errorf("MatchScore: expected %d for line %d, got %d", m.Score, m.Position, score)
+				}
+				if e := Explain(tc.query, source[m.Position], opts...); e.Score != m.Score {
+					t.Errorf("Explain: expected %d for line %d, got %+v", m.Score, m.Position, e)
+				}
+			}
+		})
+	}
+}
+
+func TestTermScores(t *testing.T) {
+	source := []string{"src/config.go", "test/config.go", "config.go"}
+
+	// the scores of the terms are added
+	combined := MatchScore("config go", source[2], WithSyntax(SyntaxTerms))
+	if expected := MatchScore("config", source[2]) + MatchScore("go", source[2]); combined != expected {
+		t.Errorf("Expected the sum of the scores %d, got %d", expected, combined)
+	}
+
+	// an optional term ranks the lines matching it first, without filtering the others
+	m := SortMatches(Find("config ~test", source, WithSyntax(SyntaxTerms)))
+	if len(m) != 3 || m[0].Position != 1 {
+		t.Errorf("Expected the line with the optional term first, got %v", m)
+	}
+
+	remapped := DefaultOperators()
+	remapped.Optional = '+'
+	m = SortMatches(Find("config +test", source, WithSyntax(SyntaxTerms), WithOperators(remapped)))
+	if len(m) != 3 || m[0].Position != 1 {
+		t.Errorf("Expected the remapped optional operator, got %v", m)
+	}
+
+	if l := LevenshteinFind("cnfig tset", source, WithSyntax(SyntaxTerms)); len(l) != 1 || l[0].Position != 1 {
+		t.Errorf("Expected the terms to use the Levenshtein distance, got %v", l)
+	}
+}
+
+func TestTermsExplain(t *testing.T) {
+	e := Explain("$.go config ~test", "src/Config.go", WithSyntax(SyntaxTerms))
+	expected := []TermResult{
+		{Term: "config", Matched: true, Score: 4},
+		{Term: "~test", Matched: false, Score: -1},
+	}
+	if e.Path != PathTerms || e.Normalized != "src/config" || !reflect.DeepEqual(e.Terms, expected) {
+		t.Errorf("Expected the terms %+v on src/config, got %+v", expected, e)
+	}
+	if score := MatchScore("$.go config ~test", "src/Config.go", WithSyntax(SyntaxTerms)); e.Score != score || score != 4+2*10+1 {
+		t.Errorf("Expected the score of MatchScore with the missing optional term, got %d and %d", e.Score, score)
+	}
+	if expected := []int{4, 5, 6, 7, 8, 9}; !reflect.DeepEqual(e.Positions, expected) {
+		t.Errorf("Expected the positions %v of \"Config\", got %v", expected, e.Positions)
+	}
+
+	// the positions of every kind of term are reported in the source
+	e = Explain("'wor ^hel !xyz go$ | java$", "hello  big world go", WithSyntax(SyntaxFZF))
+	if expected := []int{0, 1, 2, 11, 12, 13, 17, 18}; !reflect.DeepEqual(e.Positions, expected) {
+		t.Errorf("Expected the positions %v, got %+v", expected, e)
+	}
+}
@@ -0,0 +1,315 @@
+package fuzzy
+
+import (
+	_ "embed"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The transliterations of the scripts that aren't Latin (FoldPinyin, FoldRomaji, FoldCyrillic and FoldGreek).
+// They run before the other foldings, on the whole string, since a letter can change the transliteration
+// of its neighbors (e.g. "きょ" is "kyo" and "ου" is "ou").
+
+// pinyinData is the table of the pinyin of the Han characters: a syllable per line, followed by its characters.
+// A character is in a single line, even if it has more readings (see FoldPinyin).
+//
+//go:embed data/pinyin.txt
+var pinyinData string
+
+// pinyin returns the pinyin of the Han characters, parsing the embedded table on the first use.
+var pinyin = sync.OnceValue(func() map[rune]string {
+	m := make(map[rune]string, 28000)
+	for line := range strings.Lines(pinyinData) {
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		syllable, chars, _ := strings.Cut(line, " ")
+		for _, r := range chars {
+			m[r] = syllable
+		}
+	}
+	return m
+})
+
+// romaji are the romaji (Hepburn) of the hiragana from "ぁ" to "ゖ".
+// The small kana have the romaji they have on their own, "っ" has none.
+var romaji = [...]string{
+	"a", "a", "i", "i", "u", "u", "e", "e", "o", "o", // ぁあぃいぅうぇえぉお
+	"ka", "ga", "ki", "gi", "ku", "gu", "ke", "ge", "ko", "go", // かがきぎくぐけげこご
+	"sa", "za", "shi", "ji", "su", "zu", "se", "ze", "so", "zo", // さざしじすずせぜそぞ
+	"ta", "da", "chi", "ji", "", "tsu", "zu", "te", "de", "to", "do", // ただちぢっつづてでとど
+	"na", "ni", "nu", "ne", "no", // なにぬねの
+	"ha", "ba", "pa", "hi", "bi", "pi", "fu", "bu", "pu", "he", "be", "pe", "ho", "bo", "po", // はばぱひびぴふぶぷへべぺほぼぽ
+	"ma", "mi", "mu", "me", "mo", // まみむめも
+	"ya", "ya", "yu", "yu", "yo", "yo", // ゃやゅゆょよ
+	"ra", "ri", "ru", "re", "ro", // らりるれろ
+	"wa", "wa", "wi", "we", "wo", "n", "vu", "ka", "ke", // ゎわゐゑをんゔゕゖ
+}
+
+// cyrillicLatin are the transliterations (BGN/PCGN, without the apostrophes) of the lowercase Cyrillic letters,
+// with the letters of Ukrainian, Belarusian, Serbian and Macedonian. "е" and "ё" are "ye" after a vowel
+// and at the start of a word (see cyrillic).
+var cyrillicLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z", 'и': "i",
+	'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t",
+	'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "",
+	'э': "e", 'ю': "yu", 'я': "ya", 'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g", 'ў': "w", 'ђ': "dj", 'ј': "j",
+	'љ': "lj", 'њ': "nj", 'ћ': "c", 'џ': "dz", 'ѓ': "gj", 'ќ': "kj", 'ѕ': "dz",
+}
+
+// greekLatin are the transliterations (ELOT 743) of the lowercase Greek letters without the accents.
+var greekLatin = map[rune]string{
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th", 'ι': "i", 'κ': "k",
+	'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t",
+	'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+// transliterates checks if the string has a rune transliterated by the foldings.
+func (s *syntax) transliterates(str string) bool {
+	if s.folding&(FoldPinyin|FoldRomaji|FoldCyrillic|FoldGreek) == 0 || isASCII(str) {
+		return false
+	}
+	for _, r := range str {
+		if s.folding&FoldPinyin != 0 && unicode.Is(unicode.Han, r) || s.folding&FoldRomaji != 0 && isKana(r) ||
+			s.folding&FoldCyrillic != 0 && unicode.Is(unicode.Cyrillic, r) || s.folding&FoldGreek != 0 && unicode.Is(unicode.Greek, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasHan checks if the string has a Han character.
+func hasHan(s string) bool {
+	if isASCII(s) {
+		return false
+	}
+	for _, r := range s {
+		if unicode.Is(unicode.Han, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// transliterate replaces the Han characters with their pinyin (FoldPinyin), the kana with their romaji (FoldRomaji)
+// and the Cyrillic and Greek letters with the Latin ones (FoldCyrillic and FoldGreek).
+// It keeps the offsets as normalizeAt does, if at isn't nil.
+// With foldInitials the Han characters are replaced with the initial of their pinyin, e.g. "北京" is "bj".
+func (s *syntax) transliterate(str string, at []int) (string, []int) {
+	if !s.transliterates(str) {
+		return str, at
+	}
+
+	t := transliterator{b: make([]byte, 0, 2*len(str)), at: at}
+	if at != nil {
+		t.offsets = make([]int, 0, 2*len(str))
+	}
+	prev := ""       // the romaji of the previous kana
+	last := rune(-1) // the rune of the input before r, for the letters that depend on it
+	for i, r := 0, rune(-1); i < len(str); last = r {
+		var size int
+		r, size = utf8.DecodeRuneInString(str[i:])
+		if s.folding&FoldCyrillic != 0 {
+			if l, ok := cyrillic(r, last); ok {
+				t.put(0, l, i)
+				i, prev = i+size, ""
+				continue
+			}
+		}
+		if s.folding&FoldGreek != 0 {
+			if l, n := greek(str[i:], last); n > 0 {
+				t.put(0, l, i)
+				i, prev = i+n, ""
+				continue
+			}
+		}
+		if s.folding&FoldPinyin != 0 && unicode.Is(unicode.Han, r) {
+			if p, ok := pinyin()[r]; ok {
+				if s.folding&foldInitials != 0 {
+					p = p[:1]
+				}
+				t.put(0, p, i)
+				i, prev = i+size, ""
+				continue
+			}
+		}
+		if s.folding&FoldRomaji != 0 {
+			if k, n := kanaAt(str[i:]); n > 0 {
+				prev = t.romaji(k, str[i+n:], prev, i)
+				i += n
+				continue
+			}
+		}
+
+		t.copy(str[i:i+size], i)
+		i, prev = i+size, ""
+	}
+
+	return string(t.b), t.offsets
+}
+
+// cyrillic returns the Latin transliteration of the Cyrillic letter r, given the rune before it.
+// The transliteration of an uppercase letter is capitalized, e.g. "Ж" is "Zh".
+func cyrillic(r, prev rune) (string, bool) {
+	l := unicode.ToLower(r)
+	s, ok := cyrillicLatin[l]
+	if !ok {
+		return "", false
+	}
+	if l == 'е' || l == 'ё' {
+		// "ye" at the start of a word and after a vowel or a sign, e.g. "Елена" is "Yelena"
+		if p := unicode.ToLower(prev); !unicode.IsLetter(prev) || strings.ContainsRune("аеёиоуыэюяєіїъь", p) {
+			s = "ye"
+		}
+	}
+	return capitalize(s, r != l), true
+}
+
+// greek returns the Latin transliteration of the Greek letter at the start of s, given the rune before it,
+// and the length in bytes of the letters transliterated. The length is 0 if s doesn't start with a Greek letter.
+// The accents are dropped, and the letters that depend on their neighbors follow ELOT 743: "ου" is "ou",
+// "αυ", "ευ" and "ηυ" are "av", "ev" and "iv", "γ" is "n" before "γ", "ξ" and "χ", and "μπ" and "ντ"
+// are "b" and "d" at the start of a word.
+func greek(s string, prev rune) (string, int) {
+	r, size := utf8.DecodeRuneInString(s)
+	l, diaeresis := greekBase(r)
+	t, ok := greekLatin[l]
+	if !ok {
+		return "", 0
+	}
+	next, n := utf8.DecodeRuneInString(s[size:])
+	nl, _ := greekBase(next)
+	p, _ := greekBase(prev)
+
+	switch {
+	case l == 'υ' && !diaeresis && p == 'ο':
+		t = "u"
+	case l == 'υ' && !diaeresis && (p == 'α' || p == 'ε' || p == 'η'):
+		t = "v"
+	case l == 'γ' && (nl == 'γ' || nl == 'ξ' || nl == 'χ'):
+		t = "n"
+	case l == 'μ' && nl == 'π' && !unicode.IsLetter(prev):
+		t, size = "b", size+n
+	case l == 'ν' && nl == 'τ' && !unicode.IsLetter(prev):
+		t, size = "d", size+n
+	}
+	return capitalize(t, unicode.IsUpper(r)), size
+}
+
+// greekBase returns the lowercase Greek letter without its accents, and whether it had a diaeresis
+// (which keeps it out of a diphthong, e.g. "αϋ" is "ay").
+func greekBase(r rune) (rune, bool) {
+	diaeresis := false
+	if d, ok := decompositions[r]; ok {
+		r, _ = utf8.DecodeRuneInString(d)
+		diaeresis = strings.ContainsRune(d, '\u0308')
+	}
+	return unicode.ToLower(r), diaeresis
+}
+
+// capitalize uppercases the first letter of the transliteration, if upper is true.
+func capitalize(s string, upper bool) string {
+	if !upper || s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// transliterator builds a transliterated string.
+type transliterator struct {
+	b       []byte
+	at      []int // the offsets of the input, nil if they aren't kept
+	offsets []int // the offsets of the bytes of b
+}
+
+// put removes the last trim bytes and appends the transliteration of the rune at the index i of the input.
+func (t *transliterator) put(trim int, s string, i int) {
+	t.b = append(t.b[:len(t.b)-trim], s...)
+	if t.at != nil {
+		t.offsets = t.offsets[:len(t.offsets)-trim]
+		for range len(s) {
+			t.offsets = append(t.offsets, t.at[i])
+		}
+	}
+}
+
+// copy appends the bytes of the input at the index i as they are.
+func (t *transliterator) copy(s string, i int) {
+	t.b = append(t.b, s...)
+	if t.at != nil {
+		t.offsets = append(t.offsets, t.at[i:i+len(s)]...)
+	}
+}
+
+// romaji appends the romaji of the hiragana k, given the input after it (for "っ") and the romaji of the kana
+// before it, if any (for the small kana, e.g. "きょ" is "kyo" and "ファ" is "fa").
+// It returns the romaji of the kana, combined with the one before if they make a single syllable.
+func (t *transliterator) romaji(k rune, next, prev string, i int) string {
+	switch {
+	case k == 'ー':
+		// the long vowels are written without the mark, as they are typed (e.g. "ramen")
+		return prev
+	case k == 'っ':
+		// the next consonant is doubled, e.g. "がっこう" is "gakkou"
+		if n, size := kanaAt(next); size > 0 {
+			if c := kanaRomaji(n); c != "" && !strings.ContainsRune("aiueon", rune(c[0])) {
+				if strings.HasPrefix(c, "ch") {
+					c = "t"
+				}
+				t.put(0, c[:1], i)
+			}
+		}
+		return ""
+	case (k == 'ゃ' || k == 'ゅ' || k == 'ょ') && len(prev) > 1 && strings.HasSuffix(prev, "i"):
+		v := kanaRomaji(k)[1:]
+		if prev != "shi" && prev != "chi" && prev != "ji" {
+			v = "y" + v
+		}
+		t.put(1, v, i)
+		return prev[:len(prev)-1] + v
+	case (k == 'ぁ' || k == 'ぃ' || k == 'ぅ' || k == 'ぇ' || k == 'ぉ') && len(prev) > 1:
+		// the small vowels replace the vowel of the kana before
+		v := kanaRomaji(k)
+		t.put(1, v, i)
+		return prev[:len(prev)-1] + v
+	}
+	r := kanaRomaji(k)
+	t.put(0, r, i)
+	return r
+}
+
+// kanaRomaji returns the romaji of the hiragana (or of the katakana without a hiragana, e.g. "ヷ").
+func kanaRomaji(k rune) string {
+	switch {
+	case 'ぁ' <= k && k <= 'ゖ':
+		return romaji[k-'ぁ']
+	case 'ヷ' <= k && k <= 'ヺ':
+		return [...]string{"va", "vi", "ve", "vo"}[k-'ヷ']
+	}
+	return ""
+}
+
+// isKana checks if the rune is a kana, full-width or half-width, or the prolonged sound mark.
+func isKana(r rune) bool {
+	return 'ぁ' <= r && r <= 'ゖ' || 'ァ' <= r && r <= 'ヺ' || r == 'ー' || 'ｦ' <= r && r <= 'ﾝ'
+}
+
+// kanaAt returns the hiragana of the kana at the start of s, composed with the sound mark after it if any,
+// and the length in bytes of both. The length is 0 if s doesn't start with a kana.
+func kanaAt(s string) (rune, int) {
+	r, size := utf8.DecodeRuneInString(s)
+	if !isKana(r) {
+		return r, 0
+	}
+
+	k := narrow(r)
+	if m, n := utf8.DecodeRuneInString(s[size:]); n > 0 {
+		if v := voiced(k, m == '゚' || m == 'ﾟ'); v != k && (m == '゙' || m == 'ﾞ' || m == '゚' || m == 'ﾟ') {
+			k, size = v, size+n
+		}
+	}
+	return hiragana(k), size
+}
@@ -0,0 +1,233 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTransliterate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		folding  Folding
+		input    string
+		expected string
+	}{
+		{name: "Pinyin", folding: FoldPinyin, input: "北京市", expected: "beijingshi"},
+		{name: "Pinyin with other text", folding: FoldPinyin, input: "上海 Shanghai", expected: "shanghai Shanghai"},
+		{name: "Pinyin ü", folding: FoldPinyin, input: "女", expected: "nv"},
+		{name: "Initials", folding: FoldPinyin | foldInitials, input: "北京大学", expected: "bjdx"},
+		{name: "Kana without FoldRomaji", folding: FoldPinyin, input: "東京とうきょう", expected: "dongjingとうきょう"},
+		{name: "Hiragana", folding: FoldRomaji, input: "とうきょう", expected: "toukyou"},
+		{name: "Katakana", folding: FoldRomaji, input: "カタカナ", expected: "katakana"},
+		{name: "Long vowels", folding: FoldRomaji, input: "ラーメン", expected: "ramen"},
+		{name: "Double consonants", folding: FoldRomaji, input: "がっこう マッチ", expected: "gakkou matchi"},
+		{name: "Contracted sounds", folding: FoldRomaji, input: "ちょっと じゃない きゃく", expected: "chotto janai kyaku"},
+		{name: "Small vowels", folding: FoldRomaji, input: "ファイル ティー", expected: "fairu ti"},
+		{name: "Half-width", folding: FoldRomaji, input: "ｶﾞｯｺｳ", expected: "gakkou"},
+		{name: "Small kana on their own", folding: FoldRomaji, input: "ょ ぁ", expected: "yo a"},
+		{name: "Cyrillic", folding: FoldCyrillic, input: "Дмитрий Щукин", expected: "Dmitriy Shchukin"},
+		{name: "Cyrillic ye", folding: FoldCyrillic, input: "Елена Объект Сергеев", expected: "Yelena Obyekt Sergeyev"},
+		{name: "Ukrainian", folding: FoldCyrillic, input: "Київ Євген", expected: "Kiyiv Yevgen"},
+		{name: "Serbian", folding: FoldCyrillic, input: "Љубљана Ђорђе", expected: "Ljubljana Djordje"},
+		{name: "Greek", folding: FoldGreek, input: "Αθήνα Θεσσαλονίκη", expected: "Athina Thessaloniki"},
+		{name: "Greek diphthongs", folding: FoldGreek, input: "Ευαγγελία Πλούταρχος", expected: "Evangelia Ploutarchos"},
+		{name: "Greek diaeresis", folding: FoldGreek, input: "αϋπνία", expected: "aypnia"},
+		{name: "Greek initial digraphs", folding: FoldGreek, input: "Μπάμπης Ντίνος", expected: "Bampis Dinos"},
+		{name: "Scripts not folded", folding: FoldCyrillic, input: "Αθήνα Москва", expected: "Αθήνα Moskva"},
+		{name: "Without transliterations", folding: FoldAll, input: "北京 Москва", expected: "北京 Москва"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			syn := syntax{folding: tc.folding}
+			if result, _ := syn.transliterate(tc.input, nil); result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+
+			at := make([]int, len(tc.input))
+			for i := range at {
+				at[i] = i
+			}
+			if result, offsets := syn.transliterate(tc.input, at); result != tc.expected || len(offsets) != len(result) {
+				t.Errorf("Expected %q with an offset for every byte, got %q and %v", tc.expected, result, offsets)
+			}
+		})
+	}
+}
+
+func TestFindTransliterated(t *testing.T) {
+	source := []string{"北京", "北京大学", "南京", "東京", "とうきょう", "トーキョー", "Beijing"}
+
+	testCases := []struct {
+		name     string
+		query    string
+		opts     []Option
+		expected []int
+	}{
+		{name: "No transliteration", query: "beijing", expected: []int{6}},
+		{name: "Pinyin", query: "beijing", opts: []Option{WithFolding(FoldPinyin)}, expected: []int{0, 1, 6}},
+		{name: "Initials", query: "bj", opts: []Option{WithFolding(FoldPinyin)}, expected: []int{0, 1, 6}},
+		{name: "More initials", query: "bjdx", opts: []Option{WithFolding(FoldPinyin)}, expected: []int{1}},
+		{name: "Han query", query: "北京", opts: []Option{WithFolding(FoldPinyin)}, expected: []int{0, 1, 6}},
+		{name: "Romaji", query: "tokyo", opts: []Option{WithFolding(FoldRomaji)}, expected: []int{4, 5}},
+		{name: "Both", query: "dongjing toukyou", opts: []Option{WithFolding(FoldPinyin | FoldRomaji), WithSyntax(SyntaxTerms)}, expected: []int{}},
+		{name: "Both with alternatives", query: "dongjing | toukyou", opts: []Option{WithFolding(FoldPinyin | FoldRomaji), WithSyntax(SyntaxFZF)}, expected: []int{3, 4}},
+		{name: "Filters", query: "^bei jing", opts: []Option{WithFolding(FoldPinyin)}, expected: []int{0, 1, 6}},
+		{name: "Initials with filters", query: "$jing bj", opts: []Option{WithFolding(FoldPinyin)}, expected: []int{0}},
+		{name: "Initials with negated filters", query: "!*daxue bj", opts: []Option{WithFolding(FoldPinyin)}, expected: []int{0, 6}},
+		{name: "Terms", query: "daxue bj", opts: []Option{WithFolding(FoldPinyin), WithSyntax(SyntaxTerms)}, expected: []int{1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := make([]int, 0)
+			for _, m := range Find(tc.query, source, tc.opts...) {
+				result = append(result, m.Position)
+			}
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Expected lines %v, got %v", tc.expected, result)
+			}
+
+			expected := Find(tc.query, source, tc.opts...)
+			if m := NewCorpus(source).Find(tc.query, tc.opts...); !reflect.DeepEqual(m, expected) {
+				t.Errorf("Corpus.Find: expected %v, got %v", expected, m)
+			}
+			if m := NewArena(source).Find(tc.query, tc.opts...); !reflect.DeepEqual(m, expected) {
+				t.Errorf("Arena.Find: expected %v, got %v", expected, m)
+			}
+			if m, err := SafeFind(tc.query, source, Limits{}, tc.opts...); err != nil || !reflect.DeepEqual(m, expected) {
+				t.Errorf("SafeFind: expected %v, got %v %v", expected, m, err)
+			}
+			for _, m := range expected {
+				if e := Explain(tc.query, source[m.Position], tc.opts...); e.Score != m.Score {
+					t.Errorf("Explain: expected %d for line %d, got %+v", m.Score, m.Position, e)
+				}
+			}
+		})
+	}
+}
+
+func TestFindCyrillicGreek(t *testing.T) {
+	source := []string{"Дмитрий Иванов", "Dmitriy Ivanov", "Dmitry Petrov", "Αθήνα", "Athina", "Елена"}
+
+	testCases := []struct {
+		name     string
+		query    string
+		opts     []Option
+		expected []int
+	}{
+		{name: "No transliteration", query: "dmitriy", expected: []int{1}},
+		{name: "Latin query", query: "dmitriy", opts: []Option{WithFolding(FoldCyrillic)}, expected: []int{0, 1}},
+		{name: "Cyrillic query", query: "дмитрий", opts: []Option{WithFolding(FoldCyrillic)}, expected: []int{0, 1}},
+		{name: "Sensitive query", query: "Ivanov", opts: []Option{WithFolding(FoldCyrillic)}, expected: []int{0, 1}},
+		{name: "Fuzzy", query: "dmitry", opts: []Option{WithFolding(FoldCyrillic)}, expected: []int{0, 1, 2}},
+		{name: "Greek", query: "athina", opts: []Option{WithFolding(FoldGreek)}, expected: []int{3, 4}},
+		{name: "Greek query", query: "αθηνα", opts: []Option{WithFolding(FoldGreek)}, expected: []int{3, 4}},
+		{name: "Filters", query: "$ivanov dmitriy", opts: []Option{WithFolding(FoldCyrillic)}, expected: []int{0, 1}},
+		{name: "Terms", query: "ivanov дмитрий", opts: []Option{WithFolding(FoldCyrillic), WithSyntax(SyntaxTerms)}, expected: []int{0, 1}},
+		{name: "With the other foldings", query: "yelena", opts: []Option{WithFolding(FoldAll | FoldCyrillic)}, expected: []int{5}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := make([]int, 0)
+			for _, m := range Find(tc.query, source, tc.opts...) {
+				result = append(result, m.Position)
+			}
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Expected lines %v, got %v", tc.expected, result)
+			}
+
+			expected := Find(tc.query, source, tc.opts...)
+			if m := NewCorpus(source).Find(tc.query, tc.opts...); !reflect.DeepEqual(m, expected) {
+				t.Errorf("Corpus.Find: expected %v, got %v", expected, m)
+			}
+			if m := NewCorpus(source).LevenshteinFind(tc.query, tc.opts...); !reflect.DeepEqual(m, LevenshteinFind(tc.query, source, tc.opts...)) {
+				t.Errorf("Corpus.LevenshteinFind: expected the same matches of LevenshteinFind, got %v", m)
+			}
+			for _, m := range expected {
+				if e := Explain(tc.query, source[m.Position], tc.opts...); e.Score != m.Score {
+					t.Errorf("Explain: expected %d for line %d, got %+v", m.Score, m.Position, e)
+				}
+			}
+		})
+	}
+
+	if m := LevenshteinFind("dmitriy ivanov", source, WithFolding(FoldCyrillic)); len(m) < 2 || m[0].Score != 0 || m[1].Score != 0 {
+		t.Errorf("Expected both scripts to match with no distance, got %v", m)
+	}
+}
+
+func TestLevenshteinTransliterated(t *testing.T) {
+	source := []string{"北京", "北京大学", "南京"}
+
+	m := LevenshteinFind("bj", source, WithFolding(FoldPinyin))
+	if len(m) != 2 || m[0].Position != 0 || m[0].Score != 0 {
+		t.Errorf("Expected the initials to match with the Levenshtein distance, got %v", m)
+	}
+	if m := LevenshteinFind("beijnig", source, WithFolding(FoldPinyin)); len(m) == 0 || m[0].Position != 0 {
+		t.Errorf("Expected the pinyin to match with a typo, got %v", m)
+	}
+	if m := NewCorpus(source).LevenshteinFind("bj", WithFolding(FoldPinyin)); !reflect.DeepEqual(m, LevenshteinFind("bj", source, WithFolding(FoldPinyin))) {
+		t.Errorf("Corpus.LevenshteinFind: expected the same matches of LevenshteinFind, got %v", m)
+	}
+
+	var stats Stats
+	Find("bj", source, WithFolding(FoldPinyin), WithStats(&stats))
+	if stats.Lines != 3 || stats.Matches != 2 {
+		t.Errorf("Expected every line counted once, got %+v", stats)
+	}
+}
+
+func TestTransliteratedPositions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		source   string
+		opts     []Option
+		expected []int
+	}{
+		{name: "Pinyin", query: "jing", source: "北京", opts: []Option{WithFolding(FoldPinyin)}, expected: []int{3}},
+		{name: "Initials", query: "bj", source: "在北京", opts: []Option{WithFolding(FoldPinyin)}, expected: []int{3, 6}},
+		{name: "Romaji", query: "kyo", source: "とうきょう", opts: []Option{WithFolding(FoldRomaji)}, expected: []int{6, 9}},
+		{name: "Mixed", query: "bjcity", source: "北京 city", opts: []Option{WithFolding(FoldPinyin)}, expected: []int{0, 3, 7, 8, 9, 10}},
+		{name: "Cyrillic", query: "shchuk", source: "Щукин", opts: []Option{WithFolding(FoldCyrillic)}, expected: []int{0, 2, 4}},
+		{name: "Greek", query: "thina", source: "Αθήνα", opts: []Option{WithFolding(FoldGreek)}, expected: []int{2, 4, 6, 8}},
+		{name: "Greek digraph", query: "bamp", source: "Μπάμπης", opts: []Option{WithFolding(FoldGreek)}, expected: []int{0, 4, 6, 8}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := Explain(tc.query, tc.source, tc.opts...)
+			if !reflect.DeepEqual(e.Positions, tc.expected) {
+				t.Errorf("Expected the positions %v, got %+v", tc.expected, e)
+			}
+			if score := MatchScore(tc.query, tc.source, tc.opts...); e.Score != score {
+				t.Errorf("Expected score %d (MatchScore), got %d", score, e.Score)
+			}
+		})
+	}
+}
+
+func TestPinyinSingleReading(t *testing.T) {
+	// the characters with more readings have a single one, so only the Han query matches them
+	source := []string{"长城", "银行"}
+	testCases := []struct {
+		query    string
+		expected int
+	}{
+		{query: "zhangcheng", expected: 1},
+		{query: "changcheng", expected: 0},
+		{query: "yinxing", expected: 1},
+		{query: "yinhang", expected: 0},
+		{query: "银行", expected: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			if m := Find(tc.query, source, WithFolding(FoldPinyin)); len(m) != tc.expected {
+				t.Errorf("Expected %d matches, got %v", tc.expected, m)
+			}
+		})
+	}
+}